@@ -0,0 +1,356 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// IntArray is a slice of ints which is written to the database as a Postgres array, e.g. INT[]. Zero elements are
+// written as NULL elements, and an empty array is written as NULL.
+type IntArray[T constraints.Signed] []T
+
+// IsZero returns whether this is the zero value
+func (a IntArray[T]) IsZero() bool { return len(a) == 0 }
+
+// Scan implements the Scanner interface
+func (a *IntArray[T]) Scan(value any) error { return ScanIntArray(value, a) }
+
+// Value implements the Valuer interface
+func (a IntArray[T]) Value() (driver.Value, error) { return IntArrayValue(a) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (a *IntArray[T]) UnmarshalJSON(b []byte) error { return unmarshalArrayJSON(b, (*[]T)(a)) }
+
+// MarshalJSON implements the Marshaller interface
+func (a IntArray[T]) MarshalJSON() ([]byte, error) { return json.Marshal([]T(a)) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (a *IntArray[T]) UnmarshalText(b []byte) error { return UnmarshalIntArrayText(b, a) }
+
+// MarshalText implements the TextMarshaler interface
+func (a IntArray[T]) MarshalText() ([]byte, error) { return MarshalIntArrayText(a) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (a *IntArray[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(a, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (a IntArray[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(a, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (a IntArray[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(a, name) }
+
+// StringArray is a slice of strings which is written to the database as a Postgres array, e.g. TEXT[]. Empty
+// elements are written as NULL elements, and an empty array is written as NULL.
+type StringArray[T ~string] []T
+
+// IsZero returns whether this is the zero value
+func (a StringArray[T]) IsZero() bool { return len(a) == 0 }
+
+// Scan implements the Scanner interface
+func (a *StringArray[T]) Scan(value any) error { return ScanStringArray(value, a) }
+
+// Value implements the Valuer interface
+func (a StringArray[T]) Value() (driver.Value, error) { return StringArrayValue(a) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (a *StringArray[T]) UnmarshalJSON(b []byte) error { return unmarshalArrayJSON(b, (*[]T)(a)) }
+
+// MarshalJSON implements the Marshaller interface
+func (a StringArray[T]) MarshalJSON() ([]byte, error) { return json.Marshal([]T(a)) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (a *StringArray[T]) UnmarshalText(b []byte) error { return UnmarshalStringArrayText(b, a) }
+
+// MarshalText implements the TextMarshaler interface
+func (a StringArray[T]) MarshalText() ([]byte, error) { return MarshalStringArrayText(a) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (a *StringArray[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(a, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (a StringArray[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(a, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (a StringArray[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return MarshalXMLAttr(a, name)
+}
+
+// ScanIntArray scans a nullable Postgres array into an int array, using an empty array for NULL and zero for NULL
+// elements.
+func ScanIntArray[T constraints.Signed](value any, a *IntArray[T]) error {
+	elems, err := scanArray(value)
+	if err != nil {
+		return err
+	}
+
+	scanned := make(IntArray[T], len(elems))
+	for i, e := range elems {
+		if e == nil {
+			continue
+		}
+
+		v, err := strconv.ParseInt(*e, 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to scan array element %q as int", *e)
+		}
+		if err := convertInt(v, &scanned[i]); err != nil {
+			return err
+		}
+	}
+
+	*a = scanned
+	return nil
+}
+
+// IntArrayValue converts an int array to NULL if it is empty, otherwise to a Postgres array literal.
+func IntArrayValue[T constraints.Signed](a IntArray[T]) (driver.Value, error) {
+	if len(a) == 0 {
+		return nil, nil
+	}
+
+	elems := make([]string, len(a))
+	for i, v := range a {
+		if v == 0 {
+			elems[i] = "NULL"
+		} else {
+			elems[i] = strconv.FormatInt(int64(v), 10)
+		}
+	}
+	return "{" + strings.Join(elems, ",") + "}", nil
+}
+
+// UnmarshalIntArrayText unmarshals an int array from Postgres array text, using an empty array for empty text.
+func UnmarshalIntArrayText[T constraints.Signed](b []byte, a *IntArray[T]) error {
+	return ScanIntArray(string(b), a)
+}
+
+// MarshalIntArrayText marshals an int array to Postgres array text, using empty text for an empty array.
+func MarshalIntArrayText[T constraints.Signed](a IntArray[T]) ([]byte, error) {
+	v, err := IntArrayValue(a)
+	return arrayText(v, err)
+}
+
+// ScanStringArray scans a nullable Postgres array into a string array, using an empty array for NULL and empty
+// strings for NULL elements.
+func ScanStringArray[T ~string](value any, a *StringArray[T]) error {
+	elems, err := scanArray(value)
+	if err != nil {
+		return err
+	}
+
+	scanned := make(StringArray[T], len(elems))
+	for i, e := range elems {
+		if e != nil {
+			scanned[i] = T(*e)
+		}
+	}
+
+	*a = scanned
+	return nil
+}
+
+// StringArrayValue converts a string array to NULL if it is empty, otherwise to a Postgres array literal.
+func StringArrayValue[T ~string](a StringArray[T]) (driver.Value, error) {
+	if len(a) == 0 {
+		return nil, nil
+	}
+
+	b := &strings.Builder{}
+	b.WriteByte('{')
+	for i, v := range a {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if v == "" {
+			b.WriteString("NULL")
+		} else {
+			b.WriteByte('"')
+			for _, c := range []byte(v) {
+				if c == '"' || c == '\\' {
+					b.WriteByte('\\')
+				}
+				b.WriteByte(c)
+			}
+			b.WriteByte('"')
+		}
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// UnmarshalStringArrayText unmarshals a string array from Postgres array text, using an empty array for empty text.
+func UnmarshalStringArrayText[T ~string](b []byte, a *StringArray[T]) error {
+	return ScanStringArray(string(b), a)
+}
+
+// MarshalStringArrayText marshals a string array to Postgres array text, using empty text for an empty array.
+func MarshalStringArrayText[T ~string](a StringArray[T]) ([]byte, error) {
+	v, err := StringArrayValue(a)
+	return arrayText(v, err)
+}
+
+// converts the result of an array Value helper to text
+func arrayText(v driver.Value, err error) ([]byte, error) {
+	if err != nil || v == nil {
+		return []byte{}, err
+	}
+	return []byte(v.(string)), nil
+}
+
+// unmarshals a JSON array into a new slice, which keeps arrays being written to JSON as arrays rather than as their
+// text, using an empty slice for null like scanning does
+func unmarshalArrayJSON[T any](b []byte, a *[]T) error {
+	var u []T
+	if err := json.Unmarshal(b, &u); err != nil {
+		return err
+	}
+	if u == nil {
+		u = []T{}
+	}
+
+	*a = u
+	return nil
+}
+
+// scans the elements of a nullable Postgres array, with nil for NULL elements
+func scanArray(value any) ([]*string, error) {
+	var raw string
+	switch typed := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		raw = typed
+	case []byte:
+		raw = string(typed)
+	default:
+		return nil, fmt.Errorf("unable to scan %T as array", value)
+	}
+
+	// empty text is same as nil
+	if raw == "" {
+		return nil, nil
+	}
+
+	elems, err := parseArray(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan %q as array: %w", raw, err)
+	}
+	return elems, nil
+}
+
+// parses a one-dimensional Postgres array literal like {1,NULL,"a \"b\""} into its elements, with nil for NULL
+func parseArray(s string) ([]*string, error) {
+	s = strings.TrimSpace(s)
+
+	// strip any dimension decoration, e.g. [0:2]={1,2,3}
+	if strings.HasPrefix(s, "[") {
+		eq := strings.Index(s, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("invalid array dimensions")
+		}
+		s = strings.TrimSpace(s[eq+1:])
+	}
+
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("array must be enclosed in braces")
+	}
+	s = s[1 : len(s)-1]
+
+	if strings.TrimSpace(s) == "" {
+		return []*string{}, nil
+	}
+
+	elems := make([]*string, 0, strings.Count(s, ",")+1)
+	i := 0
+
+	for {
+		for i < len(s) && isArraySpace(s[i]) {
+			i++
+		}
+		if i == len(s) {
+			return nil, fmt.Errorf("missing array element")
+		}
+
+		var elem *string
+
+		switch s[i] {
+		case '{':
+			return nil, fmt.Errorf("multi-dimensional arrays are not supported")
+		case '"':
+			b := &strings.Builder{}
+			i++
+			for {
+				if i == len(s) {
+					return nil, fmt.Errorf("unterminated quoted array element")
+				}
+				c := s[i]
+				i++
+				if c == '"' {
+					break
+				}
+				if c == '\\' {
+					if i == len(s) {
+						return nil, fmt.Errorf("unterminated quoted array element")
+					}
+					c = s[i]
+					i++
+				}
+				b.WriteByte(c)
+			}
+			str := b.String()
+			elem = &str
+		default:
+			b := &strings.Builder{}
+			for i < len(s) && s[i] != ',' {
+				c := s[i]
+				if c == '"' || c == '{' || c == '}' {
+					return nil, fmt.Errorf("unexpected %q in array element", c)
+				}
+				if c == '\\' && i+1 < len(s) {
+					i++
+					c = s[i]
+				}
+				b.WriteByte(c)
+				i++
+			}
+			str := strings.TrimRightFunc(b.String(), func(r rune) bool { return r < 128 && isArraySpace(byte(r)) })
+			if str == "" {
+				return nil, fmt.Errorf("missing array element")
+			}
+			if !strings.EqualFold(str, "NULL") {
+				elem = &str
+			}
+		}
+
+		elems = append(elems, elem)
+
+		for i < len(s) && isArraySpace(s[i]) {
+			i++
+		}
+		if i == len(s) {
+			return elems, nil
+		}
+		if s[i] != ',' {
+			return nil, fmt.Errorf("unexpected %q after array element", s[i])
+		}
+		i++
+	}
+}
+
+func isArraySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
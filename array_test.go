@@ -0,0 +1,232 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntArray(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value INT[] NULL);`)
+
+	tcs := []struct {
+		value   null.IntArray[null.Int64]
+		dbValue driver.Value
+	}{
+		{null.IntArray[null.Int64]{1, 2, 3}, "{1,2,3}"},
+		{null.IntArray[null.Int64]{1, null.NullInt64, -3}, "{1,NULL,-3}"},
+		{null.IntArray[null.Int64]{null.NullInt64}, "{NULL}"},
+		{null.IntArray[null.Int64]{}, nil},
+		{null.IntArray[null.Int64](nil), nil},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		scanned := null.IntArray[null.Int64]{}
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		// we never return a nil array even if that's what we wrote
+		expected := tc.value
+		if expected == nil {
+			expected = null.IntArray[null.Int64]{}
+		}
+
+		assert.Equal(t, expected, scanned, "scanned value mismatch for %v", tc.value)
+	}
+}
+
+func TestStringArray(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value TEXT[] NULL);`)
+
+	tcs := []struct {
+		value   null.StringArray[null.String]
+		dbValue driver.Value
+	}{
+		{null.StringArray[null.String]{"foo", "bar"}, `{"foo","bar"}`},
+		{null.StringArray[null.String]{"foo", null.NullString, "NULL"}, `{"foo",NULL,"NULL"}`},
+		{null.StringArray[null.String]{`a "quoted" \ value`, "a,b", "{}", " "}, `{"a \"quoted\" \\ value","a,b","{}"," "}`},
+		{null.StringArray[null.String]{}, nil},
+		{null.StringArray[null.String](nil), nil},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		scanned := null.StringArray[null.String]{}
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		// we never return a nil array even if that's what we wrote
+		expected := tc.value
+		if expected == nil {
+			expected = null.StringArray[null.String]{}
+		}
+
+		assert.Equal(t, expected, scanned, "scanned value mismatch for %v", tc.value)
+	}
+}
+
+func TestScanArray(t *testing.T) {
+	ints := []struct {
+		value    any
+		expected null.IntArray[int32]
+	}{
+		{nil, null.IntArray[int32]{}},
+		{"", null.IntArray[int32]{}},
+		{"{}", null.IntArray[int32]{}},
+		{"{1,NULL,3}", null.IntArray[int32]{1, 0, 3}},
+		{[]byte("{1,null,3}"), null.IntArray[int32]{1, 0, 3}},
+		{"{ 1 , 2 ,3 }", null.IntArray[int32]{1, 2, 3}},
+		{`{"1","2"}`, null.IntArray[int32]{1, 2}},
+		{"[0:1]={1,2}", null.IntArray[int32]{1, 2}},
+	}
+
+	for _, tc := range ints {
+		scanned := null.IntArray[int32]{7}
+		err := scanned.Scan(tc.value)
+		assert.NoError(t, err, "unexpected error scanning %v", tc.value)
+		assert.Equal(t, tc.expected, scanned, "scanned value mismatch for %v", tc.value)
+	}
+
+	strs := []struct {
+		value    any
+		expected null.StringArray[string]
+	}{
+		{nil, null.StringArray[string]{}},
+		{"{}", null.StringArray[string]{}},
+		{`{foo,NULL,"NULL",""}`, null.StringArray[string]{"foo", "", "NULL", ""}},
+		{`{"a \"quoted\" \\ value","a,b","{}"}`, null.StringArray[string]{`a "quoted" \ value`, "a,b", "{}"}},
+		{`{  foo bar  ," baz "}`, null.StringArray[string]{"foo bar", " baz "}},
+		{`{a\,b}`, null.StringArray[string]{"a,b"}},
+		{`{"ümlaut","😀"}`, null.StringArray[string]{"ümlaut", "😀"}},
+	}
+
+	for _, tc := range strs {
+		scanned := null.StringArray[string]{"x"}
+		err := scanned.Scan(tc.value)
+		assert.NoError(t, err, "unexpected error scanning %v", tc.value)
+		assert.Equal(t, tc.expected, scanned, "scanned value mismatch for %v", tc.value)
+	}
+
+	var a null.StringArray[string]
+	assert.EqualError(t, a.Scan(123), "unable to scan int as array")
+	assert.EqualError(t, a.Scan("foo"), `unable to scan "foo" as array: array must be enclosed in braces`)
+	assert.EqualError(t, a.Scan("{{1,2},{3,4}}"), `unable to scan "{{1,2},{3,4}}" as array: multi-dimensional arrays are not supported`)
+	assert.EqualError(t, a.Scan(`{"foo}`), `unable to scan "{\"foo}" as array: unterminated quoted array element`)
+	assert.EqualError(t, a.Scan(`{foo,}`), `unable to scan "{foo,}" as array: missing array element`)
+	assert.EqualError(t, a.Scan(`{"foo"bar}`), `unable to scan "{\"foo\"bar}" as array: unexpected 'b' after array element`)
+
+	var i null.IntArray[int8]
+	assert.EqualError(t, i.Scan("{1,foo}"), `unable to scan array element "foo" as int`)
+	assert.EqualError(t, i.Scan("{1,300}"), "value 300 is out of range for int8")
+
+	// written values can be parsed back
+	v, err := null.StringArray[string]{`a "quoted" \ value`, "", "a,b"}.Value()
+	assert.NoError(t, err)
+	assert.NoError(t, a.Scan(v))
+	assert.Equal(t, null.StringArray[string]{`a "quoted" \ value`, "", "a,b"}, a)
+}
+
+func TestArrayEncodings(t *testing.T) {
+	ints := null.IntArray[int32]{1, 2, 3}
+	assert.False(t, ints.IsZero())
+	assert.True(t, null.IntArray[int32]{}.IsZero())
+
+	// text is the Postgres array literal
+	b, err := ints.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, `{1,2,3}`, string(b))
+	b, err = null.IntArray[int32]{}.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, ``, string(b))
+
+	var i null.IntArray[int32]
+	assert.NoError(t, i.UnmarshalText([]byte(`{4,5}`)))
+	assert.Equal(t, null.IntArray[int32]{4, 5}, i)
+	assert.NoError(t, i.UnmarshalText([]byte(``)))
+	assert.Equal(t, null.IntArray[int32]{}, i)
+	assert.EqualError(t, i.UnmarshalText([]byte(`{x}`)), `unable to scan array element "x" as int`)
+
+	strs := null.StringArray[string]{"a,b", ""}
+	b, err = strs.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a,b",NULL}`, string(b))
+
+	var s null.StringArray[string]
+	assert.NoError(t, s.UnmarshalText(b))
+	assert.Equal(t, strs, s)
+
+	// JSON is still written as arrays rather than text
+	b, err = json.Marshal(ints)
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2,3]`, string(b))
+	b, err = json.Marshal(null.IntArray[int32](nil))
+	assert.NoError(t, err)
+	assert.Equal(t, `null`, string(b))
+	b, err = json.Marshal(strs)
+	assert.NoError(t, err)
+	assert.Equal(t, `["a,b",""]`, string(b))
+
+	i = null.IntArray[int32]{7, 8, 9}
+	assert.NoError(t, json.Unmarshal([]byte(`[1]`), &i))
+	assert.Equal(t, null.IntArray[int32]{1}, i)
+
+	// null is unmarshaled as an empty array, the same as scanning NULL
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &i))
+	assert.Equal(t, null.IntArray[int32]{}, i)
+	assert.NoError(t, i.Scan(nil))
+	assert.Equal(t, null.IntArray[int32]{}, i)
+
+	s = null.StringArray[string]{"x"}
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &s))
+	assert.Equal(t, null.StringArray[string]{}, s)
+	assert.NoError(t, s.Scan(nil))
+	assert.Equal(t, null.StringArray[string]{}, s)
+
+	// and XML as text
+	type doc struct {
+		Ints null.IntArray[int32]     `xml:"ints"`
+		Strs null.StringArray[string] `xml:"strs,attr,omitempty"`
+	}
+	b, err = xml.Marshal(doc{Ints: ints, Strs: strs})
+	assert.NoError(t, err)
+	assert.Equal(t, `<doc strs="{&#34;a,b&#34;,NULL}"><ints>{1,2,3}</ints></doc>`, string(b))
+
+	var d doc
+	assert.NoError(t, xml.Unmarshal(b, &d))
+	assert.Equal(t, doc{Ints: ints, Strs: strs}, d)
+}
@@ -0,0 +1,126 @@
+package null
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+// Bool is a bool that will write as null when it is false, both to databases and JSON
+// null values when unmarshalled or scanned from a DB will result in a false value.
+type Bool bool
+
+// NullBool is our constant for a Bool value that will be written as null
+const NullBool = Bool(false)
+
+// IsZero returns whether this is the zero value
+func (b Bool) IsZero() bool { return b == NullBool }
+
+// Scan implements the Scanner interface
+func (b *Bool) Scan(value any) error { return ScanBool(value, b) }
+
+// Value implements the Valuer interface
+func (b Bool) Value() (driver.Value, error) { return BoolValue(b) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (b *Bool) UnmarshalJSON(d []byte) error { return UnmarshalBool(d, b) }
+
+// MarshalJSON implements the Marshaller interface
+func (b Bool) MarshalJSON() ([]byte, error) { return MarshalBool(b) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (b *Bool) UnmarshalText(d []byte) error { return UnmarshalBoolText(d, b) }
+
+// MarshalText implements the TextMarshaler interface
+func (b Bool) MarshalText() ([]byte, error) { return MarshalBoolText(b) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (b *Bool) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(b, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (b Bool) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(b, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (b Bool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(b, name) }
+
+// ScanBool scans a nullable BOOLEAN into a bool type, using false for NULL. Textual values like "t", "true" and "1"
+// are also accepted as returned by some drivers.
+func ScanBool[T ~bool](value any, b *T) error {
+	nb := sql.NullBool{}
+
+	if err := nb.Scan(value); err != nil {
+		return err
+	}
+
+	if !nb.Valid {
+		*b = false
+		return nil
+	}
+
+	*b = T(nb.Bool)
+	return nil
+}
+
+// BoolValue converts a bool type value to NULL if it is false.
+func BoolValue[T ~bool](b T) (driver.Value, error) {
+	if !b {
+		return nil, nil
+	}
+	return true, nil
+}
+
+// UnmarshalBool unmarshals a bool type from JSON, using false for null.
+func UnmarshalBool[T ~bool](d []byte, b *T) error {
+	var val *bool
+
+	if err := json.Unmarshal(d, &val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		*b = false
+		return nil
+	}
+
+	*b = T(*val)
+	return nil
+}
+
+// MarshalBool marshals a bool type to JSON, using null for false.
+func MarshalBool[T ~bool](b T) ([]byte, error) {
+	if !b {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(true)
+}
+
+// UnmarshalBoolText unmarshals a bool type from text, using false for empty text.
+func UnmarshalBoolText[T ~bool](d []byte, b *T) error {
+	if len(d) == 0 {
+		*b = false
+		return nil
+	}
+
+	v, err := strconv.ParseBool(string(d))
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal %q as bool: %w", d, err)
+	}
+
+	*b = T(v)
+	return nil
+}
+
+// MarshalBoolText marshals a bool type to text, using empty text for false.
+func MarshalBoolText[T ~bool](b T) ([]byte, error) {
+	if !b {
+		return []byte{}, nil
+	}
+	return []byte("true"), nil
+}
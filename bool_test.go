@@ -0,0 +1,93 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBool(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value BOOLEAN NULL);`)
+
+	tcs := []struct {
+		value     null.Bool
+		dbValue   driver.Value
+		marshaled []byte
+	}{
+		{null.Bool(true), true, []byte(`true`)},
+		{null.NullBool, nil, []byte(`null`)},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		var scanned null.Bool
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tc.value, scanned, "scanned value mismatch for %v", tc.value)
+
+		marshaled, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+		var unmarshaled null.Bool
+		err = json.Unmarshal(marshaled, &unmarshaled)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+type CustomFlag bool
+
+func (f *CustomFlag) Scan(value any) error         { return null.ScanBool(value, f) }
+func (f CustomFlag) Value() (driver.Value, error)  { return null.BoolValue(f) }
+func (f *CustomFlag) UnmarshalJSON(b []byte) error { return null.UnmarshalBool(b, f) }
+func (f CustomFlag) MarshalJSON() ([]byte, error)  { return null.MarshalBool(f) }
+
+func TestScanBool(t *testing.T) {
+	tcs := []struct {
+		value    any
+		expected CustomFlag
+	}{
+		{nil, false},
+		{true, true},
+		{false, false},
+		{"t", true},
+		{"true", true},
+		{"1", true},
+		{[]byte("t"), true},
+		{"f", false},
+		{"false", false},
+		{"0", false},
+		{int64(1), true},
+		{int64(0), false},
+	}
+
+	for _, tc := range tcs {
+		f := CustomFlag(true)
+		err := f.Scan(tc.value)
+		assert.NoError(t, err, "unexpected error scanning %v", tc.value)
+		assert.Equal(t, tc.expected, f, "scanned value mismatch for %v", tc.value)
+	}
+
+	var f CustomFlag
+	assert.Error(t, f.Scan("maybe"))
+}
@@ -0,0 +1,272 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// canonicalizes JSON according to RFC 8785, i.e. without whitespace, with object keys sorted by their UTF-16 code
+// units, with strings minimally escaped and with numbers formatted as ECMAScript does
+func canonicalizeJSON(b []byte) ([]byte, error) {
+	if !json.Valid(b) {
+		return nil, fmt.Errorf("unable to canonicalize invalid JSON")
+	}
+
+	v, err := decodeJSONValue(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return appendCanonicalJSON(make([]byte, 0, len(b)), v)
+}
+
+// decodes JSON into generic values, keeping numbers as json.Number so that they aren't changed by a round trip
+func decodeJSONValue(b []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func appendCanonicalJSON(dst []byte, v any) ([]byte, error) {
+	var err error
+
+	switch typed := v.(type) {
+	case nil:
+		dst = append(dst, "null"...)
+	case bool:
+		dst = strconv.AppendBool(dst, typed)
+	case json.Number:
+		f, _ := strconv.ParseFloat(string(typed), 64)
+		if math.IsInf(f, 0) {
+			return nil, fmt.Errorf("unable to canonicalize %s as it is out of range for a double", typed)
+		}
+		dst = appendCanonicalNumber(dst, f)
+	case string:
+		dst = appendCanonicalString(dst, typed)
+	case []any:
+		dst = append(dst, '[')
+		for i, e := range typed {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			if dst, err = appendCanonicalJSON(dst, e); err != nil {
+				return nil, err
+			}
+		}
+		dst = append(dst, ']')
+	case map[string]any:
+		keys := make([]string, 0, len(typed))
+		for k := range typed {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return lessUTF16(keys[i], keys[j]) })
+
+		dst = append(dst, '{')
+		for i, k := range keys {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendCanonicalString(dst, k)
+			dst = append(dst, ':')
+			if dst, err = appendCanonicalJSON(dst, typed[k]); err != nil {
+				return nil, err
+			}
+		}
+		dst = append(dst, '}')
+	}
+
+	return dst, nil
+}
+
+// appends a finite float using the same formatting as ECMAScript's Number.prototype.toString
+func appendCanonicalNumber(dst []byte, f float64) []byte {
+	if f == 0 {
+		return append(dst, '0') // includes -0
+	}
+	if f < 0 {
+		dst = append(dst, '-')
+		f = -f
+	}
+
+	// get the shortest digits which round trip and the exponent, e.g. 1.2345e+06
+	mantissa, exp, _ := strings.Cut(strconv.FormatFloat(f, 'e', -1, 64), "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	e, _ := strconv.Atoi(exp)
+
+	// the position of the decimal point relative to the start of the digits
+	k, n := len(digits), e+1
+
+	switch {
+	case k <= n && n <= 21:
+		dst = append(dst, digits...)
+		dst = append(dst, strings.Repeat("0", n-k)...)
+	case 0 < n && n <= 21:
+		dst = append(dst, digits[:n]...)
+		dst = append(dst, '.')
+		dst = append(dst, digits[n:]...)
+	case -6 < n && n <= 0:
+		dst = append(dst, "0."...)
+		dst = append(dst, strings.Repeat("0", -n)...)
+		dst = append(dst, digits...)
+	default:
+		dst = append(dst, digits[0])
+		if k > 1 {
+			dst = append(dst, '.')
+			dst = append(dst, digits[1:]...)
+		}
+		dst = append(dst, 'e')
+		if n > 0 {
+			dst = append(dst, '+')
+		}
+		dst = strconv.AppendInt(dst, int64(n-1), 10)
+	}
+	return dst
+}
+
+// appends a string with only quotes, backslashes and control characters escaped
+func appendCanonicalString(dst []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+
+	dst = append(dst, '"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch c {
+		case '"', '\\':
+			dst = append(dst, '\\', c)
+		case '\b':
+			dst = append(dst, '\\', 'b')
+		case '\t':
+			dst = append(dst, '\\', 't')
+		case '\n':
+			dst = append(dst, '\\', 'n')
+		case '\f':
+			dst = append(dst, '\\', 'f')
+		case '\r':
+			dst = append(dst, '\\', 'r')
+		default:
+			if c < 0x20 {
+				dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+			} else {
+				dst = append(dst, c)
+			}
+		}
+	}
+	return append(dst, '"')
+}
+
+// returns whether a sorts before b when compared as UTF-16 code units. This is the same as comparing code points except
+// that characters outside the BMP are encoded as surrogates which sort before U+E000-U+FFFF, so we can compare the UTF-8
+// directly without having to encode either string.
+func lessUTF16(a, b string) bool {
+	for len(a) > 0 && len(b) > 0 {
+		ra, sa := utf8.DecodeRuneInString(a)
+		rb, sb := utf8.DecodeRuneInString(b)
+
+		if ra != rb {
+			ua, ub := firstUTF16Unit(ra), firstUTF16Unit(rb)
+			if ua != ub {
+				return ua < ub
+			}
+			return ra < rb // same high surrogate so low surrogates are in code point order
+		}
+
+		a, b = a[sa:], b[sb:]
+	}
+	return len(a) < len(b)
+}
+
+// returns the first UTF-16 code unit of a rune, i.e. the rune itself or its high surrogate
+func firstUTF16Unit(r rune) rune {
+	if r >= 0x10000 {
+		high, _ := utf16.EncodeRune(r)
+		return high
+	}
+	return r
+}
+
+// returns whether two decoded JSON values are semantically equal, comparing numbers exactly rather than as doubles
+func jsonValuesEqual(a, b any) bool {
+	switch ta := a.(type) {
+	case nil:
+		return b == nil
+	case bool:
+		tb, ok := b.(bool)
+		return ok && ta == tb
+	case string:
+		tb, ok := b.(string)
+		return ok && ta == tb
+	case json.Number:
+		tb, ok := b.(json.Number)
+		return ok && normalizeJSONNumber(ta) == normalizeJSONNumber(tb)
+	case []any:
+		tb, ok := b.([]any)
+		if !ok || len(ta) != len(tb) {
+			return false
+		}
+		for i := range ta {
+			if !jsonValuesEqual(ta[i], tb[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		tb, ok := b.(map[string]any)
+		if !ok || len(ta) != len(tb) {
+			return false
+		}
+		for k, va := range ta {
+			vb, exists := tb[k]
+			if !exists || !jsonValuesEqual(va, vb) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// normalizes a JSON number to digits without leading or trailing zeros and an exponent, e.g. 1.50 and 15e-1 both become
+// 15e-1, so that numbers with the same value are always written the same way
+func normalizeJSONNumber(n json.Number) string {
+	s := string(n)
+
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+
+	// the exponent is kept as a big.Int since it could be any size
+	exp := new(big.Int)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp.SetString(s[i+1:], 10)
+		s = s[:i]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	digits := strings.TrimLeft(whole+frac, "0")
+	if digits == "" {
+		return "0" // includes -0
+	}
+
+	trimmed := strings.TrimRight(digits, "0")
+	exp.Add(exp, big.NewInt(int64(len(digits)-len(trimmed)-len(frac))))
+
+	if neg {
+		return "-" + trimmed + "e" + exp.String()
+	}
+	return trimmed + "e" + exp.String()
+}
@@ -0,0 +1,225 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// Date is a calendar date without a time or location, e.g. for DATE columns. It will write as null when it is zero,
+// both to databases and JSON. null values when unmarshalled or scanned from a DB will result in a zero date value.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NullDate is our constant for a Date value that will be written as null
+var NullDate = Date{}
+
+const dateLayout = "2006-01-02"
+
+// DateOf returns the date of the given time in that time's location.
+func DateOf(t time.Time) Date {
+	if t.IsZero() {
+		return NullDate
+	}
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// ParseDate parses an RFC 3339 full-date, i.e. YYYY-MM-DD.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return NullDate, err
+	}
+	return DateOf(t), nil
+}
+
+// IsNull returns whether this date is the zero date.
+func (d Date) IsNull() bool { return d == NullDate }
+
+// In returns the time at midnight of this date in the given location, or the zero time if this date is zero.
+func (d Date) In(loc *time.Location) time.Time {
+	if d.IsNull() {
+		return time.Time{}
+	}
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// String returns this date formatted as YYYY-MM-DD.
+func (d Date) String() string { return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day) }
+
+// IsZero returns whether this is the zero value
+func (d Date) IsZero() bool { return d.IsNull() }
+
+// Scan implements the Scanner interface
+func (d *Date) Scan(value any) error { return ScanDate(value, d) }
+
+// Value implements the Valuer interface
+func (d Date) Value() (driver.Value, error) { return DateValue(d) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (d *Date) UnmarshalJSON(b []byte) error { return UnmarshalDate(b, d) }
+
+// MarshalJSON implements the Marshaller interface
+func (d Date) MarshalJSON() ([]byte, error) { return MarshalDate(d) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (d *Date) UnmarshalText(b []byte) error { return UnmarshalDateText(b, d) }
+
+// MarshalText implements the TextMarshaler interface
+func (d Date) MarshalText() ([]byte, error) { return MarshalDateText(d) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (d *Date) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(d, dec, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (d Date) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(d, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (d Date) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(d, name) }
+
+// ScanDate scans a nullable DATE, TIMESTAMP or text value into a date, using the zero date for NULL. Times are
+// converted to dates in their own location and text values can be a full-date or an RFC 3339 or Postgres timestamp.
+func ScanDate(value any, d *Date) error {
+	switch typed := value.(type) {
+	case nil:
+		*d = NullDate
+		return nil
+	case time.Time:
+		*d = DateOf(typed)
+		return nil
+	case string:
+		return scanDateText(typed, d)
+	case []byte:
+		return scanDateText(string(typed), d)
+	default:
+		return fmt.Errorf("unable to scan %T as date", value)
+	}
+}
+
+func scanDateText(s string, d *Date) error {
+	if s == "" {
+		*d = NullDate
+		return nil
+	}
+
+	// full-date with optional time part after it
+	date, rest := s, ""
+	if len(s) > len(dateLayout) {
+		date, rest = s[:len(dateLayout)], s[len(dateLayout):]
+	}
+
+	if parsed, err := ParseDate(date); err == nil && (rest == "" || isTimeSuffix(rest)) {
+		*d = parsed
+		return nil
+	}
+
+	return fmt.Errorf("unable to scan %q as date", s)
+}
+
+// layouts of the time part of RFC 3339 and Postgres timestamps, with fractional seconds being accepted by all of them
+var timeLayouts = []string{"15:04:05", "15:04:05Z07:00", "15:04:05-07", "15:04:05-0700", "15:04:05-07:00:00"}
+
+// checks whether the given text is a space or T followed by a valid time
+func isTimeSuffix(s string) bool {
+	if s[0] != ' ' && s[0] != 'T' {
+		return false
+	}
+
+	for _, layout := range timeLayouts {
+		if _, err := time.Parse(layout, s[1:]); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// DateValue converts a date to NULL if it is zero, returning an error if it isn't a valid date.
+func DateValue(d Date) (driver.Value, error) {
+	if d.IsNull() {
+		return nil, nil
+	}
+	if err := validateDate(d); err != nil {
+		return nil, err
+	}
+	return d.String(), nil
+}
+
+// checks that a date is a real date which can be written as a full-date, e.g. not February 31st
+func validateDate(d Date) error {
+	y, m, day := time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC).Date()
+
+	if y != d.Year || m != d.Month || day != d.Day || d.Year < 0 || d.Year > 9999 {
+		return fmt.Errorf("%s isn't a valid date", d)
+	}
+	return nil
+}
+
+// UnmarshalDate unmarshals a date from a JSON full-date string, using the zero date for null.
+func UnmarshalDate(b []byte, d *Date) error {
+	var val *string
+
+	if err := json.Unmarshal(b, &val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		*d = NullDate
+		return nil
+	}
+
+	parsed, err := ParseDate(*val)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
+
+// MarshalDate marshals a date to a JSON full-date string, using null for the zero date.
+func MarshalDate(d Date) ([]byte, error) {
+	if d.IsNull() {
+		return json.Marshal(nil)
+	}
+	if err := validateDate(d); err != nil {
+		return nil, err
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalDateText unmarshals a date from full-date text, using the zero date for empty text.
+func UnmarshalDateText(b []byte, d *Date) error {
+	if len(b) == 0 {
+		*d = NullDate
+		return nil
+	}
+
+	parsed, err := ParseDate(string(b))
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
+
+// MarshalDateText marshals a date to full-date text, using empty text for the zero date.
+func MarshalDateText(d Date) ([]byte, error) {
+	if d.IsNull() {
+		return []byte{}, nil
+	}
+	if err := validateDate(d); err != nil {
+		return nil, err
+	}
+	return []byte(d.String()), nil
+}
@@ -0,0 +1,151 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDate(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value DATE NULL);`)
+
+	tcs := []struct {
+		value     null.Date
+		dbValue   driver.Value
+		marshaled []byte
+	}{
+		{null.Date{Year: 2026, Month: 10, Day: 17}, "2026-10-17", []byte(`"2026-10-17"`)},
+		{null.Date{Year: 1, Month: 1, Day: 1}, "0001-01-01", []byte(`"0001-01-01"`)},
+		{null.NullDate, nil, []byte(`null`)},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		var scanned null.Date
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tc.value, scanned, "scanned value mismatch for %v", tc.value)
+
+		marshaled, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+		var unmarshaled null.Date
+		err = json.Unmarshal(marshaled, &unmarshaled)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+func TestScanDate(t *testing.T) {
+	d1 := null.Date{Year: 2026, Month: 10, Day: 17}
+	nzt := time.FixedZone("NZT", 13*60*60)
+
+	tcs := []struct {
+		value    any
+		expected null.Date
+	}{
+		{nil, null.NullDate},
+		{"", null.NullDate},
+		{time.Time{}, null.NullDate},
+		{time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), d1},
+		{time.Date(2026, 10, 17, 23, 59, 0, 0, nzt), d1},
+		{"2026-10-17", d1},
+		{[]byte("2026-10-17"), d1},
+		{"2026-10-17T15:30:00Z", d1},
+		{"2026-10-17 15:30:00+00", d1},
+		{"2026-10-17 15:30:00", d1},
+		{"2026-10-17 15:30:00.123456+05:30", d1},
+		{"2026-10-17T15:30:00.5-07:00", d1},
+		{"2026-10-17 15:30:00+05:30:15", d1},
+	}
+
+	for _, tc := range tcs {
+		scanned := null.Date{Year: 2000, Month: 1, Day: 1}
+		err := scanned.Scan(tc.value)
+		assert.NoError(t, err, "unexpected error scanning %v", tc.value)
+		assert.Equal(t, tc.expected, scanned, "scanned value mismatch for %v", tc.value)
+	}
+
+	var d null.Date
+	assert.EqualError(t, d.Scan(123), "unable to scan int as date")
+	assert.EqualError(t, d.Scan("2026"), `unable to scan "2026" as date`)
+	assert.EqualError(t, d.Scan("2026-13-01"), `unable to scan "2026-13-01" as date`)
+	assert.EqualError(t, d.Scan("2026-10-17x"), `unable to scan "2026-10-17x" as date`)
+	assert.EqualError(t, d.Scan("2026-10-17 BC"), `unable to scan "2026-10-17 BC" as date`)
+	assert.EqualError(t, d.Scan("2026-10-17 15:30:00 BC"), `unable to scan "2026-10-17 15:30:00 BC" as date`)
+	assert.EqualError(t, d.Scan("2026-10-17T"), `unable to scan "2026-10-17T" as date`)
+	assert.EqualError(t, d.Scan("2026-10-17T25:00:00Z"), `unable to scan "2026-10-17T25:00:00Z" as date`)
+	assert.EqualError(t, d.Scan("2026-10-17 15:30"), `unable to scan "2026-10-17 15:30" as date`)
+	assert.EqualError(t, d.Scan("2026-10-17 15:30:00+00garbage"), `unable to scan "2026-10-17 15:30:00+00garbage" as date`)
+
+	assert.Error(t, json.Unmarshal([]byte(`"2026-10-17T15:30:00Z"`), &d))
+	assert.Error(t, json.Unmarshal([]byte(`20261017`), &d))
+}
+
+func TestDateTimeConversion(t *testing.T) {
+	nzt := time.FixedZone("NZT", 13*60*60)
+
+	d := null.DateOf(time.Date(2026, 10, 17, 23, 59, 0, 0, nzt))
+	assert.Equal(t, null.Date{Year: 2026, Month: 10, Day: 17}, d)
+	assert.Equal(t, "2026-10-17", d.String())
+	assert.False(t, d.IsNull())
+	assert.Equal(t, time.Date(2026, 10, 17, 0, 0, 0, 0, nzt), d.In(nzt))
+	assert.Equal(t, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), d.In(time.UTC))
+
+	assert.Equal(t, null.NullDate, null.DateOf(time.Time{}))
+	assert.True(t, null.NullDate.IsNull())
+	assert.True(t, null.NullDate.In(nzt).IsZero())
+
+	d, err := null.ParseDate("2024-02-29")
+	assert.NoError(t, err)
+	assert.Equal(t, null.Date{Year: 2024, Month: 2, Day: 29}, d)
+
+	_, err = null.ParseDate("2023-02-29")
+	assert.Error(t, err)
+}
+
+func TestDateValidation(t *testing.T) {
+	for _, d := range []null.Date{
+		{Year: 2026, Month: 2, Day: 31},
+		{Year: 2023, Month: 2, Day: 29},
+		{Year: 2026, Month: 13, Day: 1},
+		{Year: 2026, Month: 10, Day: 0},
+		{Year: 2026, Month: 0, Day: 0},
+		{Year: 10000, Month: 1, Day: 1},
+		{Year: -1, Month: 1, Day: 1},
+	} {
+		_, err := d.Value()
+		assert.EqualError(t, err, d.String()+" isn't a valid date")
+
+		_, err = json.Marshal(d)
+		assert.Error(t, err, "expected error marshaling %v", d)
+
+		_, err = d.MarshalText()
+		assert.Error(t, err, "expected error marshaling %v as text", d)
+	}
+
+	v, err := null.Date{Year: 2024, Month: 2, Day: 29}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-02-29", v)
+}
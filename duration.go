@@ -0,0 +1,455 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is a time.Duration that will write as null when it is zero, both to databases and JSON
+// null values when unmarshalled or scanned from a DB will result in a zero value. It is written to
+// JSON as an ISO 8601 duration string.
+type Duration time.Duration
+
+// NullDuration is our constant for a Duration value that will be written as null
+const NullDuration = Duration(0)
+
+// IsZero returns whether this is the zero value
+func (d Duration) IsZero() bool { return d == NullDuration }
+
+// Scan implements the Scanner interface
+func (d *Duration) Scan(value any) error { return ScanDuration(value, d) }
+
+// Value implements the Valuer interface
+func (d Duration) Value() (driver.Value, error) { return DurationValue(d) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (d *Duration) UnmarshalJSON(b []byte) error { return UnmarshalDuration(b, d) }
+
+// MarshalJSON implements the Marshaller interface
+func (d Duration) MarshalJSON() ([]byte, error) { return MarshalDuration(d, DurationISO8601) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (d *Duration) UnmarshalText(b []byte) error { return UnmarshalDurationText(b, d) }
+
+// MarshalText implements the TextMarshaler interface
+func (d Duration) MarshalText() ([]byte, error) { return MarshalDurationText(d, DurationISO8601) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (d *Duration) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(d, dec, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (d Duration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(d, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (d Duration) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(d, name) }
+
+// DurationFormat is the string format used when marshaling durations to JSON
+type DurationFormat int
+
+const (
+	// DurationISO8601 formats durations as ISO 8601 durations, e.g. "PT1H30M"
+	DurationISO8601 DurationFormat = iota
+
+	// DurationGo formats durations like time.Duration.String, e.g. "1h30m0s"
+	DurationGo
+)
+
+// when converting from calendar units we follow Postgres's EXTRACT(EPOCH FROM interval)
+const (
+	durationDay   = 24 * time.Hour
+	durationWeek  = 7 * durationDay
+	durationMonth = 30 * durationDay
+	durationYear  = 8766 * time.Hour // 365.25 days
+)
+
+var errDurationOverflow = errors.New("duration out of range")
+
+// ScanDuration scans a nullable INTERVAL into a duration type, using zero for NULL. Text values can be in the
+// Postgres (e.g. "1 day 02:00:00") or ISO 8601 (e.g. "P1DT2H") interval styles, and numbers are taken as seconds.
+// Months and years are converted as 30 and 365.25 days respectively.
+func ScanDuration[T ~int64](value any, d *T) error {
+	var dur time.Duration
+	var err error
+
+	switch typed := value.(type) {
+	case nil:
+	case int64:
+		dur, err = scaleDuration(strconv.FormatInt(typed, 10), time.Second)
+	case float64:
+		dur, err = scaleDuration(strconv.FormatFloat(typed, 'f', -1, 64), time.Second)
+	case string:
+		dur, err = parseInterval(typed)
+	case []byte:
+		dur, err = parseInterval(string(typed))
+	default:
+		return fmt.Errorf("unable to scan %T as duration", value)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to scan %v as duration: %w", value, err)
+	}
+
+	*d = T(dur)
+	return nil
+}
+
+// DurationValue converts a duration type value to NULL if it is zero, otherwise it is written as an ISO 8601 string
+// which Postgres will accept as an INTERVAL.
+func DurationValue[T ~int64](d T) (driver.Value, error) {
+	if d == 0 {
+		return nil, nil
+	}
+	return formatISODuration(time.Duration(d)), nil
+}
+
+// UnmarshalDuration unmarshals a duration type from JSON, using zero for null. Strings can be ISO 8601 or Go
+// duration strings, and numbers are taken as seconds.
+func UnmarshalDuration[T ~int64](b []byte, d *T) error {
+	var val any
+
+	dec := json.NewDecoder(strings.NewReader(string(b)))
+	dec.UseNumber()
+	if err := dec.Decode(&val); err != nil {
+		return err
+	}
+
+	var dur time.Duration
+	var err error
+
+	switch typed := val.(type) {
+	case nil:
+	case json.Number:
+		dur, err = scaleDuration(string(typed), time.Second)
+	case string:
+		if isISODuration(typed) {
+			dur, err = parseISODuration(typed)
+		} else {
+			dur, err = time.ParseDuration(typed)
+		}
+	default:
+		return fmt.Errorf("unable to unmarshal %s as duration", b)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal %s as duration: %w", b, err)
+	}
+
+	*d = T(dur)
+	return nil
+}
+
+// MarshalDuration marshals a duration type to JSON in the given format, using null for zero.
+func MarshalDuration[T ~int64](d T, format DurationFormat) ([]byte, error) {
+	if d == 0 {
+		return json.Marshal(nil)
+	}
+	if format == DurationGo {
+		return json.Marshal(time.Duration(d).String())
+	}
+	return json.Marshal(formatISODuration(time.Duration(d)))
+}
+
+// formats a duration as an ISO 8601 duration using only time components so that it's exact. Negative durations
+// have each component negated (e.g. "PT-1H-30M") which is what Postgres accepts and produces.
+func formatISODuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	sign := ""
+	u := uint64(d)
+	if d < 0 {
+		sign = "-"
+		u = uint64(-d) // works for math.MinInt64 too
+	}
+
+	hours := u / uint64(time.Hour)
+	u %= uint64(time.Hour)
+	mins := u / uint64(time.Minute)
+	u %= uint64(time.Minute)
+	secs := u / uint64(time.Second)
+	nanos := u % uint64(time.Second)
+
+	b := &strings.Builder{}
+	b.WriteString("PT")
+	if hours > 0 {
+		fmt.Fprintf(b, "%s%dH", sign, hours)
+	}
+	if mins > 0 {
+		fmt.Fprintf(b, "%s%dM", sign, mins)
+	}
+	if secs > 0 || nanos > 0 {
+		fmt.Fprintf(b, "%s%d", sign, secs)
+		if nanos > 0 {
+			b.WriteString(strings.TrimRight(fmt.Sprintf(".%09d", nanos), "0"))
+		}
+		b.WriteString("S")
+	}
+	return b.String()
+}
+
+func isISODuration(s string) bool {
+	return strings.HasPrefix(strings.TrimLeft(s, "+-"), "P")
+}
+
+// parses a Postgres interval in either the postgres or iso_8601 interval styles
+func parseInterval(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if isISODuration(s) {
+		return parseISODuration(s)
+	}
+	return parsePostgresInterval(s)
+}
+
+// parses an ISO 8601 duration like P1Y2M3DT4H5M6.5S, allowing signs on the whole or on individual components
+func parseISODuration(s string) (time.Duration, error) {
+	orig := s
+	neg := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) == 1 {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", orig)
+	}
+	s = s[1:]
+
+	var total time.Duration
+	inTime := false
+
+	for len(s) > 0 {
+		if s[0] == 'T' {
+			if inTime || len(s) == 1 {
+				return 0, fmt.Errorf("invalid ISO 8601 duration %q", orig)
+			}
+			inTime = true
+			s = s[1:]
+			continue
+		}
+
+		i := 0
+		for i < len(s) && strings.IndexByte("+-0123456789.,", s[i]) >= 0 {
+			i++
+		}
+		if i == 0 || i == len(s) {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q", orig)
+		}
+
+		var unit time.Duration
+		switch {
+		case !inTime && s[i] == 'Y':
+			unit = durationYear
+		case !inTime && s[i] == 'M':
+			unit = durationMonth
+		case !inTime && s[i] == 'W':
+			unit = durationWeek
+		case !inTime && s[i] == 'D':
+			unit = durationDay
+		case inTime && s[i] == 'H':
+			unit = time.Hour
+		case inTime && s[i] == 'M':
+			unit = time.Minute
+		case inTime && s[i] == 'S':
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q", orig)
+		}
+
+		d, err := scaleDuration(strings.Replace(s[:i], ",", ".", 1), unit)
+		if err != nil {
+			return 0, err
+		}
+		if total, err = addDurations(total, d); err != nil {
+			return 0, err
+		}
+
+		s = s[i+1:]
+	}
+
+	if neg {
+		total = -total
+	}
+	return total, nil
+}
+
+var postgresIntervalUnits = map[string]time.Duration{
+	"year": durationYear, "years": durationYear,
+	"mon": durationMonth, "mons": durationMonth,
+	"week": durationWeek, "weeks": durationWeek,
+	"day": durationDay, "days": durationDay,
+	"hour": time.Hour, "hours": time.Hour,
+	"min": time.Minute, "mins": time.Minute,
+	"sec": time.Second, "secs": time.Second,
+}
+
+// parses a Postgres interval in the default postgres style like "1 year 2 mons -3 days +04:05:06.5"
+func parsePostgresInterval(s string) (time.Duration, error) {
+	fields := strings.Fields(s)
+
+	var total time.Duration
+
+	for i := 0; i < len(fields); i++ {
+		var d time.Duration
+		var err error
+
+		if strings.Contains(fields[i], ":") {
+			d, err = parseIntervalClock(fields[i])
+		} else {
+			if i+1 == len(fields) {
+				return 0, fmt.Errorf("invalid interval %q", s)
+			}
+			unit, ok := postgresIntervalUnits[fields[i+1]]
+			if !ok {
+				return 0, fmt.Errorf("invalid interval %q", s)
+			}
+			d, err = scaleDuration(fields[i], unit)
+			i++
+		}
+
+		if err != nil {
+			return 0, err
+		}
+		if total, err = addDurations(total, d); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+// parses the time part of a Postgres interval, e.g. -01:02:03.5
+func parseIntervalClock(s string) (time.Duration, error) {
+	orig := s
+	neg := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 || strings.ContainsAny(parts[0], "+-") {
+		return 0, fmt.Errorf("invalid interval time %q", orig)
+	}
+
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	var total time.Duration
+
+	for i, p := range parts {
+		if p == "" || strings.ContainsAny(p, "+-") || (i < 2 && strings.Contains(p, ".")) {
+			return 0, fmt.Errorf("invalid interval time %q", orig)
+		}
+		d, err := scaleDuration(p, units[i])
+		if err != nil {
+			return 0, err
+		}
+		if total, err = addDurations(total, d); err != nil {
+			return 0, err
+		}
+	}
+
+	if neg {
+		total = -total
+	}
+	return total, nil
+}
+
+// returns the given decimal number of units as a duration, without losing precision for large values
+func scaleDuration(num string, unit time.Duration) (time.Duration, error) {
+	orig := num
+	neg := false
+	if strings.HasPrefix(num, "-") || strings.HasPrefix(num, "+") {
+		neg = num[0] == '-'
+		num = num[1:]
+	}
+
+	whole, frac, _ := strings.Cut(num, ".")
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("invalid number %q", orig)
+	}
+
+	var d time.Duration
+
+	if whole != "" {
+		w, err := strconv.ParseUint(whole, 10, 63)
+		if err != nil {
+			if errors.Is(err, strconv.ErrRange) {
+				return 0, errDurationOverflow
+			}
+			return 0, fmt.Errorf("invalid number %q", orig)
+		}
+		if w > uint64(math.MaxInt64/unit) {
+			return 0, errDurationOverflow
+		}
+		d = time.Duration(w) * unit
+	}
+
+	if frac != "" {
+		f, err := strconv.ParseFloat("0."+frac, 64)
+		if err != nil || strings.ContainsAny(frac, "+-eE") {
+			return 0, fmt.Errorf("invalid number %q", orig)
+		}
+		if d, err = addDurations(d, time.Duration(math.Round(f*float64(unit)))); err != nil {
+			return 0, err
+		}
+	}
+
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+func addDurations(a, b time.Duration) (time.Duration, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, errDurationOverflow
+	}
+	return a + b, nil
+}
+
+// UnmarshalDurationText unmarshals a duration type from ISO 8601 or Go duration text, using zero for empty text.
+func UnmarshalDurationText[T ~int64](b []byte, d *T) error {
+	s := string(b)
+
+	var dur time.Duration
+	var err error
+
+	switch {
+	case s == "":
+	case isISODuration(s):
+		dur, err = parseISODuration(s)
+	default:
+		dur, err = time.ParseDuration(s)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal %q as duration: %w", s, err)
+	}
+
+	*d = T(dur)
+	return nil
+}
+
+// MarshalDurationText marshals a duration type to text in the given format, using empty text for zero.
+func MarshalDurationText[T ~int64](d T, format DurationFormat) ([]byte, error) {
+	if d == 0 {
+		return []byte{}, nil
+	}
+	if format == DurationGo {
+		return []byte(time.Duration(d).String()), nil
+	}
+	return []byte(formatISODuration(time.Duration(d))), nil
+}
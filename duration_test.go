@@ -0,0 +1,164 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuration(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value INTERVAL NULL);`)
+
+	tcs := []struct {
+		value     null.Duration
+		dbValue   driver.Value
+		marshaled []byte
+	}{
+		{null.Duration(time.Hour + 2*time.Minute + 3*time.Second), "PT1H2M3S", []byte(`"PT1H2M3S"`)},
+		{null.Duration(26 * time.Hour), "PT26H", []byte(`"PT26H"`)},
+		{null.Duration(-90 * time.Minute), "PT-1H-30M", []byte(`"PT-1H-30M"`)},
+		{null.Duration(1500 * time.Millisecond), "PT1.5S", []byte(`"PT1.5S"`)},
+		{null.NullDuration, nil, []byte(`null`)},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		var scanned null.Duration
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tc.value, scanned, "scanned value mismatch for %v", tc.value)
+
+		marshaled, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+		var unmarshaled null.Duration
+		err = json.Unmarshal(marshaled, &unmarshaled)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+type CustomTimeout time.Duration
+
+func (d *CustomTimeout) Scan(value any) error         { return null.ScanDuration(value, d) }
+func (d CustomTimeout) Value() (driver.Value, error)  { return null.DurationValue(d) }
+func (d *CustomTimeout) UnmarshalJSON(b []byte) error { return null.UnmarshalDuration(b, d) }
+func (d CustomTimeout) MarshalJSON() ([]byte, error)  { return null.MarshalDuration(d, null.DurationGo) }
+
+func TestScanDuration(t *testing.T) {
+	tcs := []struct {
+		value    any
+		expected time.Duration
+	}{
+		{nil, 0},
+		{"", 0},
+		{int64(90), 90 * time.Second},
+		{float64(1.5), 1500 * time.Millisecond},
+		{"01:02:03", time.Hour + 2*time.Minute + 3*time.Second},
+		{[]byte("01:02:03"), time.Hour + 2*time.Minute + 3*time.Second},
+		{"00:00:00.000123", 123 * time.Microsecond},
+		{"-01:30:00", -90 * time.Minute},
+		{"49:00:00", 49 * time.Hour},
+		{"1 day", 24 * time.Hour},
+		{"1 day 02:00:00", 26 * time.Hour},
+		{"3 days", 72 * time.Hour},
+		{"-1 days +02:03:00", -22*time.Hour + 3*time.Minute},
+		{"1 mon", 30 * 24 * time.Hour},
+		{"1 year 2 mons", 8766*time.Hour + 60*24*time.Hour},
+		{"P1DT2H", 26 * time.Hour},
+		{"PT1H2M3.5S", time.Hour + 2*time.Minute + 3500*time.Millisecond},
+		{"P1W", 7 * 24 * time.Hour},
+		{"PT-1H-30M", -90 * time.Minute},
+		{"-PT1H30M", -90 * time.Minute},
+		{"P-1DT2H", -22 * time.Hour},
+		{"PT0,5S", 500 * time.Millisecond},
+		{"PT2562047H47M16.854775807S", math.MaxInt64},
+	}
+
+	for _, tc := range tcs {
+		scanned := null.Duration(123)
+		err := scanned.Scan(tc.value)
+		assert.NoError(t, err, "unexpected error scanning %v", tc.value)
+		assert.Equal(t, null.Duration(tc.expected), scanned, "scanned value mismatch for %v", tc.value)
+	}
+
+	errs := []any{
+		true,
+		"P",
+		"PT",
+		"P1H",
+		"PT1D",
+		"P1DT",
+		"PTH",
+		"1",
+		"1 fortnight",
+		"1:2:3:4",
+		"01:0.5:00",
+		"01:-02:00",
+		"PT2562048H",
+		"P300000Y",
+		int64(math.MaxInt64),
+	}
+
+	for _, value := range errs {
+		var d null.Duration
+		assert.Error(t, d.Scan(value), "expected error scanning %v", value)
+	}
+}
+
+func TestDurationJSON(t *testing.T) {
+	var d null.Duration
+	assert.NoError(t, json.Unmarshal([]byte(`"PT1H30M"`), &d))
+	assert.Equal(t, null.Duration(90*time.Minute), d)
+
+	assert.NoError(t, json.Unmarshal([]byte(`"1h30m"`), &d))
+	assert.Equal(t, null.Duration(90*time.Minute), d)
+
+	assert.NoError(t, json.Unmarshal([]byte(`5400`), &d))
+	assert.Equal(t, null.Duration(90*time.Minute), d)
+
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &d))
+	assert.Equal(t, null.NullDuration, d)
+
+	assert.Error(t, json.Unmarshal([]byte(`"1 hour"`), &d))
+	assert.Error(t, json.Unmarshal([]byte(`true`), &d))
+
+	// custom types can marshal as Go duration strings instead
+	b, err := json.Marshal(CustomTimeout(90 * time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, `"1h30m0s"`, string(b))
+
+	b, err = json.Marshal(CustomTimeout(0))
+	assert.NoError(t, err)
+	assert.Equal(t, `null`, string(b))
+
+	var c CustomTimeout
+	assert.NoError(t, json.Unmarshal([]byte(`"1h30m0s"`), &c))
+	assert.Equal(t, CustomTimeout(90*time.Minute), c)
+
+	b, err = null.MarshalDuration(null.Duration(math.MinInt64), null.DurationISO8601)
+	assert.NoError(t, err)
+	assert.Equal(t, `"PT-2562047H-47M-16.854775808S"`, string(b))
+}
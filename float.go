@@ -0,0 +1,198 @@
+package null
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Float64 is a float64 that will write as null when it is zero both to databases and JSON
+// null values when unmarshalled or scanned from a DB will result in a zero value.
+type Float64 float64
+
+// NullFloat64 is our constant for a Float64 value that will be written as null
+const NullFloat64 = Float64(0)
+
+// IsZero returns whether this is the zero value
+func (f Float64) IsZero() bool { return f == NullFloat64 }
+
+// Scan implements the Scanner interface
+func (f *Float64) Scan(value any) error { return ScanFloat(value, f) }
+
+// Value implements the Valuer interface
+func (f Float64) Value() (driver.Value, error) { return FloatValue(f) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (f *Float64) UnmarshalJSON(b []byte) error { return UnmarshalFloat(b, f) }
+
+// MarshalJSON implements the Marshaller interface
+func (f Float64) MarshalJSON() ([]byte, error) { return MarshalFloat(f) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (f *Float64) UnmarshalText(b []byte) error { return UnmarshalFloatText(b, f) }
+
+// MarshalText implements the TextMarshaler interface
+func (f Float64) MarshalText() ([]byte, error) { return MarshalFloatText(f) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (f *Float64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(f, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (f Float64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(f, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (f Float64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(f, name) }
+
+// Float32 is a float32 that will write as null when it is zero both to databases and JSON
+// null values when unmarshalled or scanned from a DB will result in a zero value.
+type Float32 float32
+
+// NullFloat32 is our constant for a Float32 value that will be written as null
+const NullFloat32 = Float32(0)
+
+// IsZero returns whether this is the zero value
+func (f Float32) IsZero() bool { return f == NullFloat32 }
+
+// Scan implements the Scanner interface
+func (f *Float32) Scan(value any) error { return ScanFloat(value, f) }
+
+// Value implements the Valuer interface
+func (f Float32) Value() (driver.Value, error) { return FloatValue(f) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (f *Float32) UnmarshalJSON(b []byte) error { return UnmarshalFloat(b, f) }
+
+// MarshalJSON implements the Marshaller interface
+func (f Float32) MarshalJSON() ([]byte, error) { return MarshalFloat(f) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (f *Float32) UnmarshalText(b []byte) error { return UnmarshalFloatText(b, f) }
+
+// MarshalText implements the TextMarshaler interface
+func (f Float32) MarshalText() ([]byte, error) { return MarshalFloatText(f) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (f *Float32) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(f, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (f Float32) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(f, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (f Float32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(f, name) }
+
+// ScanFloat scans a nullable FLOAT/NUMERIC into a float type, using zero for NULL.
+func ScanFloat[T constraints.Float](value any, f *T) error {
+	nf := sql.NullFloat64{}
+
+	if err := nf.Scan(value); err != nil {
+		return err
+	}
+
+	if !nf.Valid {
+		*f = T(0)
+		return nil
+	}
+
+	return convertFloat(nf.Float64, f)
+}
+
+// FloatValue converts a float type value to NULL if it is zero. NaN and ±Inf are not treated as NULL and are passed
+// through to the database as is, since Postgres float columns can store them.
+func FloatValue[T constraints.Float](f T) (driver.Value, error) {
+	if f == 0 {
+		return nil, nil
+	}
+	return float64(f), nil
+}
+
+// UnmarshalFloat unmarshals a float type from JSON, using zero for null.
+func UnmarshalFloat[T constraints.Float](b []byte, f *T) error {
+	var val *float64
+
+	if err := json.Unmarshal(b, &val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		*f = 0
+		return nil
+	}
+
+	return convertFloat(*val, f)
+}
+
+// MarshalFloat marshals a float type to JSON, using null for zero. NaN and ±Inf have no JSON representation and so,
+// like encoding/json, this returns a *json.UnsupportedValueError for them.
+func MarshalFloat[T constraints.Float](f T) ([]byte, error) {
+	if f == 0 {
+		return json.Marshal(nil)
+	}
+
+	// marshal using the type's own precision so that float32 values don't pick up noise digits
+	if floatBits(f) == 32 {
+		return json.Marshal(float32(f))
+	}
+	return json.Marshal(float64(f))
+}
+
+// UnmarshalFloatText unmarshals a float type from text, using zero for empty text.
+func UnmarshalFloatText[T constraints.Float](b []byte, f *T) error {
+	if len(b) == 0 {
+		*f = 0
+		return nil
+	}
+
+	v, err := strconv.ParseFloat(string(b), floatBits(*f))
+	if err != nil {
+		// a finite float64 which overflows a float32 is a range error, like with the int types
+		if v64, err64 := strconv.ParseFloat(string(b), 64); err64 == nil {
+			return &RangeError{Value: v64, Type: reflect.TypeOf(*f)}
+		}
+		return fmt.Errorf("unable to unmarshal %q as float: %w", b, err)
+	}
+
+	*f = T(v)
+	return nil
+}
+
+// MarshalFloatText marshals a float type to text, using empty text for zero. Unlike JSON, NaN and ±Inf can be
+// represented as text.
+func MarshalFloatText[T constraints.Float](f T) ([]byte, error) {
+	if f == 0 {
+		return []byte{}, nil
+	}
+	return strconv.AppendFloat(nil, float64(f), 'g', -1, floatBits(f)), nil
+}
+
+// sets f to v after checking that a finite v doesn't overflow the float type
+func convertFloat[T constraints.Float](v float64, f *T) error {
+	if !math.IsInf(v, 0) && math.IsInf(float64(T(v)), 0) {
+		return &RangeError{Value: v, Type: reflect.TypeOf(*f)}
+	}
+
+	*f = T(v)
+	return nil
+}
+
+// returns the size in bits of the given float type
+func floatBits[T constraints.Float](f T) int {
+	if reflect.TypeOf(f).Kind() == reflect.Float32 {
+		return 32
+	}
+	return 64
+}
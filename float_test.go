@@ -0,0 +1,165 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloat64(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value DOUBLE PRECISION NULL);`)
+
+	tcs := []struct {
+		value     null.Float64
+		dbValue   driver.Value
+		marshaled []byte
+	}{
+		{null.Float64(123.5), float64(123.5), []byte(`123.5`)},
+		{null.Float64(-0.25), float64(-0.25), []byte(`-0.25`)},
+		{null.NullFloat64, nil, []byte(`null`)},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		var scanned null.Float64
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tc.value, scanned, "scanned value mismatch for %v", tc.value)
+
+		marshaled, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+		var unmarshaled null.Float64
+		err = json.Unmarshal(marshaled, &unmarshaled)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+func TestFloat32(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value REAL NULL);`)
+
+	tcs := []struct {
+		value     null.Float32
+		dbValue   driver.Value
+		marshaled []byte
+	}{
+		{null.Float32(123.5), float64(123.5), []byte(`123.5`)},
+		{null.NullFloat32, nil, []byte(`null`)},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		var scanned null.Float32
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tc.value, scanned, "scanned value mismatch for %v", tc.value)
+
+		marshaled, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+		var unmarshaled null.Float32
+		err = json.Unmarshal(marshaled, &unmarshaled)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+func TestFloatNonFinite(t *testing.T) {
+	// float32 values marshal using float32 precision
+	b, err := null.MarshalFloat(null.Float32(0.1))
+	assert.NoError(t, err)
+	assert.Equal(t, `0.1`, string(b))
+
+	// NaN and infinities aren't valid JSON
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := null.MarshalFloat(null.Float64(f))
+		assert.IsType(t, &json.UnsupportedValueError{}, err)
+
+		_, err = json.Marshal(null.Float64(f))
+		assert.Error(t, err)
+	}
+
+	// but they can be written to the database
+	v, err := null.FloatValue(null.Float64(math.Inf(1)))
+	assert.NoError(t, err)
+	assert.Equal(t, math.Inf(1), v)
+}
+
+func TestFloatRange(t *testing.T) {
+	f := null.Float32(1.5)
+
+	err := f.Scan(float64(1e300))
+	assert.EqualError(t, err, "value 1e+300 is out of range for null.Float32")
+	assert.Equal(t, null.Float32(1.5), f) // unchanged
+
+	var rangeErr *null.RangeError
+	if assert.ErrorAs(t, err, &rangeErr) {
+		assert.Equal(t, float64(1e300), rangeErr.Value)
+		assert.Equal(t, reflect.TypeOf(null.Float32(0)), rangeErr.Type)
+	}
+
+	assert.EqualError(t, f.Scan("-1e300"), "value -1e+300 is out of range for null.Float32")
+	assert.EqualError(t, json.Unmarshal([]byte(`1e300`), &f), "value 1e+300 is out of range for null.Float32")
+	assert.EqualError(t, f.UnmarshalText([]byte(`1e300`)), "value 1e+300 is out of range for null.Float32")
+	assert.Error(t, f.UnmarshalText([]byte(`1e400`)))
+
+	// non-finite values aren't out of range
+	assert.NoError(t, f.Scan(math.Inf(-1)))
+	assert.True(t, math.IsInf(float64(f), -1))
+	assert.NoError(t, f.UnmarshalText([]byte(`+Inf`)))
+	assert.True(t, math.IsInf(float64(f), 1))
+
+	// and values which fit are still rounded to float32
+	assert.NoError(t, f.Scan(float64(math.MaxFloat32)))
+	assert.Equal(t, null.Float32(math.MaxFloat32), f)
+	assert.NoError(t, f.UnmarshalText([]byte(`0.1`)))
+	assert.Equal(t, null.Float32(0.1), f)
+
+	// as do values of generic types
+	v := null.Value[float32]{}
+	assert.EqualError(t, v.Scan(float64(1e300)), "value 1e+300 is out of range for float32")
+	assert.EqualError(t, json.Unmarshal([]byte(`1e300`), &v), "value 1e+300 is out of range for float32")
+	assert.EqualError(t, v.UnmarshalText([]byte(`1e300`)), "value 1e+300 is out of range for float32")
+	assert.NoError(t, json.Unmarshal([]byte(`2.5`), &v))
+	assert.Equal(t, float32(2.5), v.V)
+}
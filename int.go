@@ -1,9 +1,16 @@
 package null
 
 import (
+	"bytes"
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
 
 	"golang.org/x/exp/constraints"
 )
@@ -15,6 +22,9 @@ type Int int
 // NullInt is our constant for an Int value that will be written as null
 const NullInt = Int(0)
 
+// IsZero returns whether this is the zero value
+func (i Int) IsZero() bool { return i == NullInt }
+
 // Scan implements the Scanner interface
 func (i *Int) Scan(value any) error { return ScanInt(value, i) }
 
@@ -27,6 +37,23 @@ func (i *Int) UnmarshalJSON(b []byte) error { return UnmarshalInt(b, i) }
 // MarshalJSON implements the Marshaller interface
 func (i Int) MarshalJSON() ([]byte, error) { return MarshalInt(i) }
 
+// UnmarshalText implements the TextUnmarshaler interface
+func (i *Int) UnmarshalText(b []byte) error { return UnmarshalIntText(b, i) }
+
+// MarshalText implements the TextMarshaler interface
+func (i Int) MarshalText() ([]byte, error) { return MarshalIntText(i) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (i *Int) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(i, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (i Int) MarshalXML(e *xml.Encoder, start xml.StartElement) error { return MarshalXML(i, e, start) }
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (i Int) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(i, name) }
+
 // Int64 is an int64 that will write as null when it is zero both to databases and JSON
 // null values when unmarshalled or scanned from a DB will result in a zero value.
 type Int64 int64
@@ -34,6 +61,9 @@ type Int64 int64
 // NullInt64 is our constant for an Int64 value that will be written as null
 const NullInt64 = Int64(0)
 
+// IsZero returns whether this is the zero value
+func (i Int64) IsZero() bool { return i == NullInt64 }
+
 // Scan implements the Scanner interface
 func (i *Int64) Scan(value any) error { return ScanInt(value, i) }
 
@@ -46,8 +76,82 @@ func (i *Int64) UnmarshalJSON(b []byte) error { return UnmarshalInt(b, i) }
 // MarshalJSON implements the Marshaller interface
 func (i Int64) MarshalJSON() ([]byte, error) { return MarshalInt(i) }
 
+// UnmarshalText implements the TextUnmarshaler interface
+func (i *Int64) UnmarshalText(b []byte) error { return UnmarshalIntText(b, i) }
+
+// MarshalText implements the TextMarshaler interface
+func (i Int64) MarshalText() ([]byte, error) { return MarshalIntText(i) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (i *Int64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(i, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (i Int64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(i, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (i Int64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(i, name) }
+
+// Int64String is an int64 that will write as null when it is zero both to databases and JSON, and which is written to
+// JSON as a string so that values larger than 2^53 don't lose precision in JavaScript clients. It can be unmarshalled
+// from either a string or a number.
+type Int64String int64
+
+// NullInt64String is our constant for an Int64String value that will be written as null
+const NullInt64String = Int64String(0)
+
+// IsZero returns whether this is the zero value
+func (i Int64String) IsZero() bool { return i == NullInt64String }
+
+// Scan implements the Scanner interface
+func (i *Int64String) Scan(value any) error { return ScanInt(value, i) }
+
+// Value implements the Valuer interface
+func (i Int64String) Value() (driver.Value, error) { return IntValue(i) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (i *Int64String) UnmarshalJSON(b []byte) error { return UnmarshalIntFromString(b, i) }
+
+// MarshalJSON implements the Marshaller interface
+func (i Int64String) MarshalJSON() ([]byte, error) { return MarshalIntAsString(i) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (i *Int64String) UnmarshalText(b []byte) error { return UnmarshalIntText(b, i) }
+
+// MarshalText implements the TextMarshaler interface
+func (i Int64String) MarshalText() ([]byte, error) { return MarshalIntText(i) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (i *Int64String) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(i, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (i Int64String) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(i, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (i Int64String) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(i, name) }
+
 // ScanInt scans a nullable INT into an int type, using zero for NULL.
-func ScanInt[T constraints.Signed](value any, i *T) error {
+func ScanInt[T constraints.Signed](value any, i *T) error { return ScanIntSentinel(value, i, 0) }
+
+// IntValue converts an int type value to NULL if it is zero.
+func IntValue[T constraints.Signed](i T) (driver.Value, error) { return IntValueSentinel(i, 0) }
+
+// UnmarshalInt unmarshals an int type from JSON, using zero for null.
+func UnmarshalInt[T constraints.Signed](b []byte, i *T) error { return UnmarshalIntSentinel(b, i, 0) }
+
+// MarshalInt marshals an int type to JSON, using null for zero.
+func MarshalInt[T constraints.Signed](i T) ([]byte, error) { return MarshalIntSentinel(i, 0) }
+
+// ScanIntSentinel scans a nullable INT into an int type, using the given sentinel value for NULL. This is for types
+// where zero is a meaningful value and something else like -1 means no value.
+func ScanIntSentinel[T constraints.Signed](value any, i *T, sentinel T) error {
 	ni := sql.NullInt64{}
 
 	if err := ni.Scan(value); err != nil {
@@ -55,24 +159,33 @@ func ScanInt[T constraints.Signed](value any, i *T) error {
 	}
 
 	if !ni.Valid {
-		*i = T(0)
+		*i = sentinel
 		return nil
 	}
 
-	*i = T(ni.Int64)
-	return nil
+	return convertInt(ni.Int64, i)
 }
 
-// IntValue converts an int type value to NULL if it is zero.
-func IntValue[T constraints.Signed](i T) (driver.Value, error) {
-	if i == 0 {
+// IntValueSentinel converts an int type value to NULL if it is the given sentinel value.
+func IntValueSentinel[T constraints.Signed](i T, sentinel T) (driver.Value, error) {
+	if i == sentinel {
 		return nil, nil
 	}
 	return int64(i), nil
 }
 
-// UnmarshalInt unmarshals an int type from JSON, using zero for null.
-func UnmarshalInt[T constraints.Signed](b []byte, i *T) error {
+// UnmarshalIntSentinel unmarshals an int type from JSON, using the given sentinel value for null.
+func UnmarshalIntSentinel[T constraints.Signed](b []byte, i *T, sentinel T) error {
+	if string(b) == "null" {
+		*i = sentinel
+		return nil
+	}
+
+	// fast path for plain integers, anything else goes through encoding/json so that errors are the same
+	if v, ok := parseJSONInt(b); ok {
+		return convertInt(v, i)
+	}
+
 	var val *int64
 
 	if err := json.Unmarshal(b, &val); err != nil {
@@ -80,18 +193,202 @@ func UnmarshalInt[T constraints.Signed](b []byte, i *T) error {
 	}
 
 	if val == nil {
+		*i = sentinel
+		return nil
+	}
+
+	return convertInt(*val, i)
+}
+
+// MarshalIntSentinel marshals an int type to JSON, using null for the given sentinel value.
+func MarshalIntSentinel[T constraints.Signed](i T, sentinel T) ([]byte, error) {
+	if i == sentinel {
+		return []byte("null"), nil
+	}
+	return strconv.AppendInt(make([]byte, 0, 20), int64(i), 10), nil
+}
+
+// UnmarshalIntFromString unmarshals an int type from a JSON string or number, using zero for null.
+func UnmarshalIntFromString[T constraints.Signed](b []byte, i *T) error {
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		if !isJSONInt([]byte(s)) {
+			return fmt.Errorf("unable to unmarshal %s as int", b)
+		}
+		b = []byte(s)
+	}
+
+	return UnmarshalInt(b, i)
+}
+
+// MarshalIntAsString marshals an int type to a JSON string, using null for zero.
+func MarshalIntAsString[T constraints.Signed](i T) ([]byte, error) {
+	if i == 0 {
+		return []byte("null"), nil
+	}
+
+	b := make([]byte, 0, 22)
+	b = append(b, '"')
+	b = strconv.AppendInt(b, int64(i), 10)
+	return append(b, '"'), nil
+}
+
+// UnmarshalIntLenient unmarshals an int type from JSON like UnmarshalInt but also accepts integers as strings, the
+// empty string as null, and floats without fractional parts, e.g. "123", "" or 12.0.
+func UnmarshalIntLenient[T constraints.Signed](b []byte, i *T) error {
+	num := bytes.TrimSpace(b)
+
+	if len(num) > 0 && num[0] == '"' {
+		var s string
+		if err := json.Unmarshal(num, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			*i = 0
+			return nil
+		}
+		num = []byte(s)
+	} else if string(num) == "null" {
 		*i = 0
 		return nil
 	}
 
-	*i = T(*val)
+	v, err := parseLenientInt(num, reflect.TypeOf(*i))
+	if err != nil {
+		return err
+	}
+
+	return convertInt(v, i)
+}
+
+// parses a JSON number as an int64, allowing it to have a fraction or exponent as long as its value is integral
+func parseLenientInt(b []byte, typ reflect.Type) (int64, error) {
+	if !isJSONNumber(b) {
+		return 0, fmt.Errorf("unable to unmarshal %q as int", b)
+	}
+
+	s := string(b)
+
+	// strip any fraction of zeros so that values like 12.0 are parsed exactly
+	if dot := strings.IndexByte(s, '.'); dot >= 0 && !strings.ContainsAny(s, "eE") && strings.Trim(s[dot+1:], "0") == "" {
+		s = s[:dot]
+	}
+
+	if isJSONInt([]byte(s)) {
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, &RangeError{Value: s, Type: typ}
+		}
+		return v, nil
+	}
+
+	f, _ := strconv.ParseFloat(s, 64)
+	if math.IsInf(f, 0) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, &RangeError{Value: s, Type: typ}
+	}
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("unable to unmarshal %s as int: value has a fractional part", s)
+	}
+	return int64(f), nil
+}
+
+// returns whether b is a JSON number, which unlike other JSON values always starts with a minus or digit and ends
+// with a digit
+func isJSONNumber(b []byte) bool {
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+
+	return len(b) > 0 && (b[0] == '-' || isDigit(b[0])) && isDigit(b[len(b)-1]) && json.Valid(b)
+}
+
+// RangeError is the error returned when a scanned or unmarshalled value doesn't fit in the target type
+type RangeError struct {
+	Value any          // the source value, an int64, uint64, float64 or the text of a number
+	Type  reflect.Type // the target type
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("value %v is out of range for %s", e.Value, e.Type)
+}
+
+// sets i to v after checking that it fits in the int type
+func convertInt[T constraints.Signed](v int64, i *T) error {
+	if int64(T(v)) != v {
+		return &RangeError{Value: v, Type: reflect.TypeOf(*i)}
+	}
+
+	*i = T(v)
 	return nil
 }
 
-// MarshalJSON marshals an int type to JSON, using null for zero.
-func MarshalInt[T constraints.Signed](i T) ([]byte, error) {
+// parses b if it's a JSON number without a fraction or exponent which fits in an int64. Unlike strconv.ParseInt this
+// doesn't need b converted to a string, which allocates with older versions of Go.
+func parseJSONInt(b []byte) (int64, bool) {
+	if !isJSONInt(b) {
+		return 0, false
+	}
+
+	neg := b[0] == '-'
+	if neg {
+		b = b[1:]
+	}
+
+	// 19 digits can't overflow a uint64 so anything longer is out of range
+	if len(b) > 19 {
+		return 0, false
+	}
+
+	var n uint64
+	for _, c := range b {
+		n = n*10 + uint64(c-'0')
+	}
+
+	if neg && n <= -math.MinInt64 {
+		return -int64(n), true
+	}
+	if !neg && n <= math.MaxInt64 {
+		return int64(n), true
+	}
+	return 0, false
+}
+
+// returns whether b is a JSON number without a fraction or exponent
+func isJSONInt(b []byte) bool {
+	if len(b) > 0 && b[0] == '-' {
+		b = b[1:]
+	}
+	if len(b) == 0 || (b[0] == '0' && len(b) > 1) {
+		return false
+	}
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// UnmarshalIntText unmarshals an int type from text, using zero for empty text.
+func UnmarshalIntText[T constraints.Signed](b []byte, i *T) error {
+	if len(b) == 0 {
+		*i = 0
+		return nil
+	}
+
+	v, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal %q as int: %w", b, err)
+	}
+
+	return convertInt(v, i)
+}
+
+// MarshalIntText marshals an int type to text, using empty text for zero.
+func MarshalIntText[T constraints.Signed](i T) ([]byte, error) {
 	if i == 0 {
-		return json.Marshal(nil)
+		return []byte{}, nil
 	}
-	return json.Marshal(int64(i))
+	return strconv.AppendInt(nil, int64(i), 10), nil
 }
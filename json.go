@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 )
 
@@ -17,6 +18,60 @@ func (j JSON) IsNull() bool {
 	return len(j) == 0 || bytes.Equal(j, NullJSON)
 }
 
+// IsZero returns whether this is the zero value
+func (j JSON) IsZero() bool { return j.IsNull() }
+
+// Equal returns whether this JSON is semantically equal to other, i.e. ignoring whitespace, the order of object keys
+// and differences in how strings and numbers are written. Unlike Canonical, numbers are compared exactly rather than as
+// doubles so large integers like IDs are only equal if they are the same. Empty JSON is treated as null, and invalid
+// JSON is only equal to identical bytes.
+func (j JSON) Equal(other JSON) bool {
+	a, b := j, other
+	if len(a) == 0 {
+		a = NullJSON
+	}
+	if len(b) == 0 {
+		b = NullJSON
+	}
+
+	if !json.Valid(a) || !json.Valid(b) {
+		return bytes.Equal(j, other)
+	}
+
+	va, err1 := decodeJSONValue(a)
+	vb, err2 := decodeJSONValue(b)
+	return err1 == nil && err2 == nil && jsonValuesEqual(va, vb)
+}
+
+// Canonical returns the RFC 8785 canonical form of this JSON, i.e. without whitespace, with object keys sorted and with
+// numbers normalized. Empty JSON is treated as null.
+func (j JSON) Canonical() (JSON, error) {
+	if len(j) == 0 {
+		return NullJSON, nil
+	}
+	return canonicalizeJSON(j)
+}
+
+// MergePatch returns the result of applying the given RFC 7386 merge patch to this JSON, i.e. object members in the
+// patch replace those in this JSON, or remove them if they are null. Empty JSON is treated as null, but an empty patch
+// is an error rather than a null patch which would replace the whole document.
+func (j JSON) MergePatch(patch JSON) (JSON, error) {
+	if len(patch) == 0 {
+		return nil, errEmptyMergePatch
+	}
+
+	target, err := decodeMergePatchValue(j)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decodeMergePatchValue(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalMergePatchValue(applyMergePatch(target, p))
+}
+
 // Scan implements the Scanner interface
 func (j *JSON) Scan(value any) error { return ScanJSON(value, j) }
 
@@ -29,24 +84,75 @@ func (j *JSON) UnmarshalJSON(data []byte) error { return UnmarshalJSON(data, j)
 // MarshalJSON implements the Marshaller interface
 func (j JSON) MarshalJSON() ([]byte, error) { return MarshalJSON(j) }
 
-func ScanJSON(value any, j *JSON) error {
-	if value == nil {
-		*j = NullJSON
-		return nil
-	}
+// UnmarshalText implements the TextUnmarshaler interface
+func (j *JSON) UnmarshalText(b []byte) error { return UnmarshalJSONText(b, j) }
 
-	var raw []byte
-	switch typed := value.(type) {
-	case string:
-		raw = []byte(typed)
-	case []byte:
-		raw = typed
-	default:
-		return fmt.Errorf("unable to scan %T as JSON", value)
+// MarshalText implements the TextMarshaler interface
+func (j JSON) MarshalText() ([]byte, error) { return MarshalJSONText(j) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (j *JSON) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(j, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (j JSON) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(j, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (j JSON) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(j, name) }
+
+// CanonicalJSON is JSON which is converted to its RFC 8785 canonical form when written to the database, so that
+// semantically equal values are always stored as the same bytes. It is otherwise the same as JSON.
+type CanonicalJSON JSON
+
+// IsNull returns whether this JSON value is empty or contains null.
+func (j CanonicalJSON) IsNull() bool { return JSON(j).IsNull() }
+
+// IsZero returns whether this is the zero value
+func (j CanonicalJSON) IsZero() bool { return j.IsNull() }
+
+// Scan implements the Scanner interface
+func (j *CanonicalJSON) Scan(value any) error { return ScanJSON(value, (*JSON)(j)) }
+
+// Value implements the Valuer interface
+func (j CanonicalJSON) Value() (driver.Value, error) { return JSONValueCanonical(JSON(j)) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (j *CanonicalJSON) UnmarshalJSON(data []byte) error { return UnmarshalJSON(data, (*JSON)(j)) }
+
+// MarshalJSON implements the Marshaller interface
+func (j CanonicalJSON) MarshalJSON() ([]byte, error) { return MarshalJSON(JSON(j)) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (j *CanonicalJSON) UnmarshalText(b []byte) error { return UnmarshalJSONText(b, (*JSON)(j)) }
+
+// MarshalText implements the TextMarshaler interface
+func (j CanonicalJSON) MarshalText() ([]byte, error) { return MarshalJSONText(JSON(j)) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (j *CanonicalJSON) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(j, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (j CanonicalJSON) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(j, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (j CanonicalJSON) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return MarshalXMLAttr(j, name)
+}
+
+func ScanJSON(value any, j *JSON) error {
+	raw, err := scanRawJSON(value)
+	if err != nil {
+		return err
 	}
 
-	// empty bytes is same as nil
-	if len(raw) == 0 {
+	if raw == nil {
 		*j = NullJSON
 		return nil
 	}
@@ -64,6 +170,31 @@ func ScanJSON(value any, j *JSON) error {
 	return nil
 }
 
+// gets the raw bytes of a nullable text or JSON value, returning nil for NULL or empty bytes. Note that the returned
+// slice may be owned by the driver and so shouldn't be retained.
+func scanRawJSON(value any) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	var raw []byte
+	switch typed := value.(type) {
+	case string:
+		raw = []byte(typed)
+	case []byte:
+		raw = typed
+	default:
+		return nil, fmt.Errorf("unable to scan %T as JSON", value)
+	}
+
+	// empty bytes is same as nil
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	return raw, nil
+}
+
 func JSONValue(j JSON) (driver.Value, error) {
 	if j.IsNull() {
 		return nil, nil
@@ -71,6 +202,24 @@ func JSONValue(j JSON) (driver.Value, error) {
 	return []byte(j), nil
 }
 
+// JSONValueCanonical converts JSON to its RFC 8785 canonical form, or to NULL if it is null. This can be used in place
+// of JSONValue so that semantically equal values are always stored as the same bytes, like CanonicalJSON does.
+func JSONValueCanonical(j JSON) (driver.Value, error) {
+	if j.IsNull() {
+		return nil, nil
+	}
+
+	c, err := j.Canonical()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.IsNull() {
+		return nil, nil
+	}
+	return []byte(c), nil
+}
+
 func UnmarshalJSON(data []byte, j *JSON) error {
 	return json.Unmarshal(data, (*json.RawMessage)(j))
 }
@@ -81,3 +230,29 @@ func MarshalJSON(j JSON) ([]byte, error) {
 	}
 	return []byte(j), nil
 }
+
+// UnmarshalJSONText unmarshals JSON from text, using null for empty text.
+func UnmarshalJSONText(b []byte, j *JSON) error {
+	if len(b) == 0 {
+		*j = NullJSON
+		return nil
+	}
+
+	if !json.Valid(b) {
+		return fmt.Errorf("unable to unmarshal %q as JSON", b)
+	}
+
+	cloned := make([]byte, len(b))
+	copy(cloned, b)
+
+	*j = cloned
+	return nil
+}
+
+// MarshalJSONText marshals JSON to text, using empty text for null.
+func MarshalJSONText(j JSON) ([]byte, error) {
+	if j.IsNull() {
+		return []byte{}, nil
+	}
+	return []byte(j), nil
+}
@@ -3,6 +3,8 @@ package null_test
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"math"
+	"strconv"
 	"testing"
 
 	_ "github.com/lib/pq"
@@ -76,3 +78,259 @@ func TestJSON(t *testing.T) {
 	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value jsonb null);`)
 	testMap()
 }
+
+func TestJSONCanonical(t *testing.T) {
+	tcs := []struct {
+		json      null.JSON
+		canonical string
+	}{
+		{null.JSON(`{ "b": 1, "a": [true, false, null] }`), `{"a":[true,false,null],"b":1}`},
+		{null.JSON(`{"z": {"y": 1, "x": 2}, "a": {}}`), `{"a":{},"z":{"x":2,"y":1}}`},
+		{null.JSON(` null `), `null`},
+		{null.JSON(``), `null`},
+		{null.JSON(nil), `null`},
+		{null.JSON(`"\u0041\u00e9\u2028<>&\/"`), "\"Aé\u2028<>&/\""},
+
+		// example from RFC 8785 section 3.2.2
+		{
+			null.JSON(`{
+				"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+				"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+				"literals": [null, true, false]
+			}`),
+			`{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+
+		// sorting example from RFC 8785 section 3.2.3
+		{
+			null.JSON(`{"\u20ac": "Euro Sign", "\r": "Carriage Return", "\ufb33": "Hebrew Letter Dalet With Dagesh", "1": "One", "\ud83d\ude00": "Emoji: Grinning Face", "\u0080": "Control", "\u00f6": "Latin Small Letter O With Diaeresis"}`),
+			"{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"ö\":\"Latin Small Letter O With Diaeresis\",\"€\":\"Euro Sign\",\"😀\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+	}
+
+	for _, tc := range tcs {
+		canonical, err := tc.json.Canonical()
+		assert.NoError(t, err, "unexpected error for %s", tc.json)
+		assert.Equal(t, tc.canonical, string(canonical), "canonical mismatch for %s", tc.json)
+	}
+
+	_, err := null.JSON(`{"a":`).Canonical()
+	assert.EqualError(t, err, "unable to canonicalize invalid JSON")
+
+	_, err = null.JSON(`[1e400]`).Canonical()
+	assert.EqualError(t, err, "unable to canonicalize 1e400 as it is out of range for a double")
+}
+
+func TestJSONCanonicalNumbers(t *testing.T) {
+	// examples from RFC 8785 appendix B
+	tcs := []struct {
+		bits      uint64
+		canonical string
+	}{
+		{0x0000000000000000, `0`},
+		{0x8000000000000000, `0`},
+		{0x0000000000000001, `5e-324`},
+		{0x8000000000000001, `-5e-324`},
+		{0x7fefffffffffffff, `1.7976931348623157e+308`},
+		{0xffefffffffffffff, `-1.7976931348623157e+308`},
+		{0x4340000000000000, `9007199254740992`},
+		{0xc340000000000000, `-9007199254740992`},
+		{0x4430000000000000, `295147905179352830000`},
+		{0x44b52d02c7e14af5, `9.999999999999997e+22`},
+		{0x44b52d02c7e14af6, `1e+23`},
+		{0x44b52d02c7e14af7, `1.0000000000000001e+23`},
+		{0x444b1ae4d6e2ef4e, `999999999999999700000`},
+		{0x444b1ae4d6e2ef4f, `999999999999999900000`},
+		{0x444b1ae4d6e2ef50, `1e+21`},
+		{0x3eb0c6f7a0b5ed8c, `9.999999999999997e-7`},
+		{0x3eb0c6f7a0b5ed8d, `0.000001`},
+		{0x41b3de4355555553, `333333333.3333332`},
+		{0x41b3de4355555554, `333333333.33333325`},
+		{0x41b3de4355555555, `333333333.3333333`},
+		{0x41b3de4355555556, `333333333.3333334`},
+		{0x41b3de4355555557, `333333333.33333343`},
+		{0xbecbf647612f3696, `-0.0000033333333333333333`},
+		{0x43143ff3c1cb0959, `1424953923781206.2`},
+	}
+
+	for _, tc := range tcs {
+		f := math.Float64frombits(tc.bits)
+		j := null.JSON(strconv.FormatFloat(f, 'g', -1, 64))
+
+		canonical, err := j.Canonical()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.canonical, string(canonical), "canonical mismatch for %s", j)
+	}
+}
+
+func TestJSONEqual(t *testing.T) {
+	assert.True(t, null.JSON(`{"a": 1, "b": [1, 2]}`).Equal(null.JSON(`{"b":[1,2],"a":1}`)))
+	assert.True(t, null.JSON(`{"a": 1.0}`).Equal(null.JSON(`{"a":1}`)))
+	assert.True(t, null.JSON(`{"a": 1e2}`).Equal(null.JSON(`{"a":100}`)))
+	assert.True(t, null.JSON(`"\u00e9"`).Equal(null.JSON(`"é"`)))
+	assert.True(t, null.JSON(`null`).Equal(null.JSON(nil)))
+	assert.True(t, null.JSON(``).Equal(null.JSON(` null`)))
+	assert.True(t, null.JSON(`{"a"`).Equal(null.JSON(`{"a"`)))
+
+	assert.True(t, null.JSON(`[0.0, -0, 1.500e+2, 12300e-2]`).Equal(null.JSON(`[0, 0, 150, 123]`)))
+	assert.True(t, null.JSON(`{"id": 9007199254740993}`).Equal(null.JSON(`{"id": 9007199254740993.0}`)))
+
+	assert.False(t, null.JSON(`{"a": 1}`).Equal(null.JSON(`{"a": 2}`)))
+	assert.False(t, null.JSON(`{"id": 9007199254740993}`).Equal(null.JSON(`{"id": 9007199254740992}`)))
+	assert.False(t, null.JSON(`123456789012345678901234567890`).Equal(null.JSON(`123456789012345678901234567891`)))
+	assert.False(t, null.JSON(`1e1000000000000000000000`).Equal(null.JSON(`1e1000000000000000000001`)))
+	assert.False(t, null.JSON(`1`).Equal(null.JSON(`-1`)))
+	assert.False(t, null.JSON(`[1, 2]`).Equal(null.JSON(`[2, 1]`)))
+	assert.False(t, null.JSON(`{"a": 1}`).Equal(null.JSON(`{"a": 1, "b": 2}`)))
+	assert.False(t, null.JSON(`"1"`).Equal(null.JSON(`1`)))
+	assert.False(t, null.JSON(`{}`).Equal(null.JSON(`null`)))
+	assert.False(t, null.JSON(`{"a"`).Equal(null.JSON(`{"a" `)))
+}
+
+func TestJSONValueCanonical(t *testing.T) {
+	v, err := null.JSONValueCanonical(null.JSON(`{ "b": 1.50, "a": "x" }`))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`{"a":"x","b":1.5}`), v)
+
+	v, err = null.JSONValueCanonical(null.JSON(` null `))
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	v, err = null.JSONValueCanonical(null.NullJSON)
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	_, err = null.JSONValueCanonical(null.JSON(`{`))
+	assert.Error(t, err)
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	v, err := null.CanonicalJSON(`{ "b": 1.50, "a": "x" }`).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`{"a":"x","b":1.5}`), v)
+
+	v, err = null.CanonicalJSON(` null `).Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	v, err = null.CanonicalJSON(nil).Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	_, err = null.CanonicalJSON(`{`).Value()
+	assert.Error(t, err)
+
+	var j null.CanonicalJSON
+	assert.NoError(t, j.Scan([]byte(`{"b": 1}`)))
+	assert.Equal(t, null.CanonicalJSON(`{"b": 1}`), j)
+	assert.NoError(t, j.Scan(nil))
+	assert.Equal(t, null.CanonicalJSON(`null`), j)
+	assert.True(t, j.IsNull())
+	assert.Error(t, j.Scan(`{`))
+
+	// only the database value is canonicalized, JSON is left as is
+	b, err := json.Marshal(null.CanonicalJSON(`{"b": 1.50}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"b":1.50}`, string(b))
+
+	b, err = json.Marshal(null.CanonicalJSON(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, `null`, string(b))
+
+	assert.NoError(t, json.Unmarshal([]byte(`[1.0]`), &j))
+	assert.Equal(t, null.CanonicalJSON(`[1.0]`), j)
+}
+
+func TestJSONMergePatch(t *testing.T) {
+	// examples from RFC 7386 appendix A
+	tcs := []struct {
+		target   null.JSON
+		patch    null.JSON
+		expected string
+	}{
+		{null.JSON(`{"a":"b"}`), null.JSON(`{"a":"c"}`), `{"a":"c"}`},
+		{null.JSON(`{"a":"b"}`), null.JSON(`{"b":"c"}`), `{"a":"b","b":"c"}`},
+		{null.JSON(`{"a":"b"}`), null.JSON(`{"a":null}`), `{}`},
+		{null.JSON(`{"a":"b","b":"c"}`), null.JSON(`{"a":null}`), `{"b":"c"}`},
+		{null.JSON(`{"a":["b"]}`), null.JSON(`{"a":"c"}`), `{"a":"c"}`},
+		{null.JSON(`{"a":"c"}`), null.JSON(`{"a":["b"]}`), `{"a":["b"]}`},
+		{null.JSON(`{"a":{"b":"c"}}`), null.JSON(`{"a":{"b":"d","c":null}}`), `{"a":{"b":"d"}}`},
+		{null.JSON(`{"a":[{"b":"c"}]}`), null.JSON(`{"a":[1]}`), `{"a":[1]}`},
+		{null.JSON(`["a","b"]`), null.JSON(`["c","d"]`), `["c","d"]`},
+		{null.JSON(`{"a":"b"}`), null.JSON(`["c"]`), `["c"]`},
+		{null.JSON(`{"a":"foo"}`), null.JSON(`null`), `null`},
+		{null.JSON(`{"a":"foo"}`), null.JSON(`"bar"`), `"bar"`},
+		{null.JSON(`{"e":null}`), null.JSON(`{"a":1}`), `{"a":1,"e":null}`},
+		{null.JSON(`[1,2]`), null.JSON(`{"a":"b","c":null}`), `{"a":"b"}`},
+		{null.JSON(`{}`), null.JSON(`{"a":{"bb":{"ccc":null}}}`), `{"a":{"bb":{}}}`},
+
+		// numbers are kept as they are, and empty JSON is null
+		{null.JSON(`{"a": 1.50}`), null.JSON(`{"b": 12345678901234567890}`), `{"a":1.50,"b":12345678901234567890}`},
+		{null.JSON(``), null.JSON(`{"a":1}`), `{"a":1}`},
+		{null.JSON(`{"a":1}`), null.JSON(`null`), `null`},
+
+		// strings aren't HTML escaped
+		{null.JSON(`{"a":"<b>&"}`), null.JSON(`{"b":"<i>"}`), `{"a":"<b>&","b":"<i>"}`},
+	}
+
+	for _, tc := range tcs {
+		patched, err := tc.target.MergePatch(tc.patch)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, string(patched), "merge patch mismatch for %s + %s", tc.target, tc.patch)
+	}
+
+	// an empty patch is an error rather than a null patch which would clear the document
+	_, err := null.JSON(`{"a":1}`).MergePatch(nil)
+	assert.EqualError(t, err, "unable to apply empty merge patch")
+	_, err = null.JSON(`{"a":1}`).MergePatch(null.JSON(``))
+	assert.EqualError(t, err, "unable to apply empty merge patch")
+
+	_, err = null.JSON(`{"a":1}`).MergePatch(null.JSON(`{"a"`))
+	assert.EqualError(t, err, "unable to merge patch invalid JSON")
+
+	_, err = null.JSON(`{} {}`).MergePatch(null.JSON(`{}`))
+	assert.EqualError(t, err, "unable to merge patch invalid JSON")
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	tcs := []struct {
+		from  null.JSON
+		to    null.JSON
+		patch string
+	}{
+		{null.JSON(`{"a":"b"}`), null.JSON(`{"a":"c"}`), `{"a":"c"}`},
+		{null.JSON(`{"a":"b"}`), null.JSON(`{"a":"b","b":"c"}`), `{"b":"c"}`},
+		{null.JSON(`{"a":"b","b":"c"}`), null.JSON(`{"b":"c"}`), `{"a":null}`},
+		{null.JSON(`{"a":{"b":"c","d":"e"}}`), null.JSON(`{"a":{"b":"d","d":"e"}}`), `{"a":{"b":"d"}}`},
+		{null.JSON(`{"a":{"b":"c"}}`), null.JSON(`{"a":{"b":"c"}}`), `{}`},
+		{null.JSON(`{"a":{"b":"c"}}`), null.JSON(`{"a":[1]}`), `{"a":[1]}`},
+		{null.JSON(`{"a":[1,2]}`), null.JSON(`{"a":[1,2,3]}`), `{"a":[1,2,3]}`},
+		{null.JSON(`{"a":1.0}`), null.JSON(`{"a":1}`), `{}`},
+		{null.JSON(`{"id":9007199254740993}`), null.JSON(`{"id":9007199254740992}`), `{"id":9007199254740992}`},
+		{null.JSON(`{"id":9007199254740993}`), null.JSON(`{"id":9007199254740993}`), `{}`},
+		{null.JSON(`{"a":"b"}`), null.JSON(`{"a":null}`), `{"a":null}`},
+		{null.JSON(`{"a":"b"}`), null.JSON(`{"c":{"d":null,"e":1}}`), `{"a":null,"c":{"e":1}}`},
+		{null.JSON(`["a"]`), null.JSON(`["a"]`), `["a"]`},
+		{null.JSON(`["a"]`), null.JSON(`{"a":"b"}`), `{"a":"b"}`},
+		{null.JSON(`{"a":"b"}`), null.JSON(`null`), `null`},
+		{null.JSON(``), null.JSON(`{"a":"b"}`), `{"a":"b"}`},
+		{null.JSON(`{"a":"<b>"}`), null.JSON(`{"a":"<i>"}`), `{"a":"<i>"}`},
+	}
+
+	for _, tc := range tcs {
+		patch, err := null.CreateMergePatch(tc.from, tc.to)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.patch, string(patch), "patch mismatch for %s -> %s", tc.from, tc.to)
+
+		// applying the patch should give us back the new value, less any null members
+		patched, err := tc.from.MergePatch(patch)
+		assert.NoError(t, err)
+
+		expected, err := null.JSON(`null`).MergePatch(tc.to)
+		assert.NoError(t, err)
+		assert.True(t, expected.Equal(patched), "patched mismatch for %s -> %s, got %s", tc.from, tc.to, patched)
+	}
+
+	_, err := null.CreateMergePatch(null.JSON(`{`), null.JSON(`{}`))
+	assert.EqualError(t, err, "unable to merge patch invalid JSON")
+}
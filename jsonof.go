@@ -0,0 +1,123 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+)
+
+// JSONOf wraps a value of any type, e.g. a struct or slice, so that it is written to the database as JSON, and so that
+// it will write as null when it is the zero value of that type, both to databases and JSON. null values when
+// unmarshalled or scanned from a DB will result in the zero value, e.g.
+//
+//	type Settings struct {
+//	    Theme string `json:"theme"`
+//	}
+//
+//	type User struct {
+//	    ID       int                     `json:"id"`
+//	    Settings null.JSONOf[Settings] `json:"settings"`
+//	}
+type JSONOf[T any] struct {
+	V T
+}
+
+// IsNull returns whether this value is the zero value of its type.
+func (j JSONOf[T]) IsNull() bool { return isZeroValue(j.V) }
+
+// IsZero returns whether this is the zero value
+func (j JSONOf[T]) IsZero() bool { return j.IsNull() }
+
+// Scan implements the Scanner interface
+func (j *JSONOf[T]) Scan(value any) error { return ScanJSONInto(value, &j.V) }
+
+// Value implements the Valuer interface
+func (j JSONOf[T]) Value() (driver.Value, error) { return JSONValueOf(j.V) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (j *JSONOf[T]) UnmarshalJSON(b []byte) error { return unmarshalJSONInto(b, &j.V) }
+
+// MarshalJSON implements the Marshaller interface
+func (j JSONOf[T]) MarshalJSON() ([]byte, error) {
+	if j.IsNull() {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(j.V)
+}
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (j *JSONOf[T]) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		var zero T
+		j.V = zero
+		return nil
+	}
+	return unmarshalJSONInto(b, &j.V)
+}
+
+// MarshalText implements the TextMarshaler interface
+func (j JSONOf[T]) MarshalText() ([]byte, error) {
+	if j.IsNull() {
+		return []byte{}, nil
+	}
+	return json.Marshal(j.V)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (j *JSONOf[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(j, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (j JSONOf[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(j, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (j JSONOf[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(j, name) }
+
+// ScanJSONInto scans a nullable text or JSON into a new value of any type, using the zero value for NULL. This can be
+// used to implement Scan for custom types, as long as their UnmarshalJSON method doesn't also use it.
+func ScanJSONInto[T any](value any, v *T) error {
+	raw, err := scanRawJSON(value)
+	if err != nil {
+		return err
+	}
+
+	// unmarshaling always copies out of the raw bytes so it's safe for the driver to reuse them
+	if err := unmarshalJSONInto(raw, v); err != nil {
+		return fmt.Errorf("unable to scan JSON into %T: %w", *v, err)
+	}
+	return nil
+}
+
+// JSONValueOf converts a value of any type to JSON, or NULL if it is the zero value of its type. This can be used to
+// implement Value for custom types, as long as their MarshalJSON method doesn't also use it.
+func JSONValueOf[T any](v T) (driver.Value, error) {
+	if isZeroValue(v) {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// unmarshals JSON into a new value of any type, using the zero value for empty bytes or null
+func unmarshalJSONInto[T any](b []byte, v *T) error {
+	var u T
+
+	if len(b) > 0 && !bytes.Equal(bytes.TrimSpace(b), NullJSON) {
+		if err := json.Unmarshal(b, &u); err != nil {
+			return err
+		}
+	}
+
+	*v = u
+	return nil
+}
+
+// returns whether v is the zero value of its type, which unlike IsZero also works for types that aren't comparable
+func isZeroValue[T any](v T) bool {
+	return reflect.ValueOf(&v).Elem().IsZero()
+}
@@ -0,0 +1,139 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type Settings struct {
+	Theme string   `json:"theme"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+type Preferences struct {
+	Language string `json:"language"`
+}
+
+func (p *Preferences) Scan(value any) error        { return null.ScanJSONInto(value, p) }
+func (p Preferences) Value() (driver.Value, error) { return null.JSONValueOf(p) }
+
+func TestJSONOf(t *testing.T) {
+	db := getTestDB()
+
+	testJSONOf := func() {
+		tcs := []struct {
+			value     null.JSONOf[Settings]
+			dbValue   driver.Value
+			marshaled []byte
+		}{
+			{null.JSONOf[Settings]{Settings{Theme: "dark", Tags: []string{"a"}}}, []byte(`{"theme":"dark","tags":["a"]}`), []byte(`{"theme":"dark","tags":["a"]}`)},
+			{null.JSONOf[Settings]{Settings{Theme: "light"}}, []byte(`{"theme":"light"}`), []byte(`{"theme":"light"}`)},
+			{null.JSONOf[Settings]{}, nil, []byte(`null`)},
+		}
+
+		for _, tc := range tcs {
+			mustExec(db, `DELETE FROM test`)
+
+			dbValue, err := tc.value.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+			// check writing the value to the database
+			_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+			assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+			rows, err := db.Query(`SELECT value FROM test;`)
+			assert.NoError(t, err)
+
+			var scanned null.JSONOf[Settings]
+			assert.True(t, rows.Next())
+			err = rows.Scan(&scanned)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.value, scanned, "scanned value mismatch for %v", tc.value)
+
+			marshaled, err := json.Marshal(tc.value)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+			var unmarshaled null.JSONOf[Settings]
+			err = json.Unmarshal(marshaled, &unmarshaled)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.value, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+		}
+	}
+
+	// test with TEXT column
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value text null);`)
+	testJSONOf()
+
+	// test with JSONB column
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value jsonb null);`)
+	testJSONOf()
+}
+
+func TestJSONOfScan(t *testing.T) {
+	// the driver is allowed to reuse the bytes it gives us so we need to copy them
+	raw := []byte(`{"theme":"dark","tags":["a","b"]}`)
+
+	var j null.JSONOf[Settings]
+	assert.NoError(t, j.Scan(raw))
+	copy(raw, []byte(`{"theme":"pink","tags":["x","y"]}`))
+	assert.Equal(t, Settings{Theme: "dark", Tags: []string{"a", "b"}}, j.V)
+
+	// scanning always replaces the previous value rather than merging into it
+	assert.NoError(t, j.Scan(`{"theme":"light"}`))
+	assert.Equal(t, Settings{Theme: "light"}, j.V)
+
+	assert.NoError(t, j.Scan(nil))
+	assert.True(t, j.IsNull())
+	assert.NoError(t, j.Scan([]byte(`null`)))
+	assert.True(t, j.IsNull())
+
+	assert.EqualError(t, j.Scan(123), "unable to scan int as JSON")
+	assert.EqualError(t, j.Scan(`{"theme":1}`), "unable to scan JSON into null_test.Settings: json: cannot unmarshal number into Go struct field Settings.theme of type string")
+	assert.Error(t, j.Scan(`{"theme"`))
+
+	// slices work too and empty is not the same as nil
+	var s null.JSONOf[[]int]
+	assert.NoError(t, s.Scan(`[1,2]`))
+	assert.Equal(t, []int{1, 2}, s.V)
+
+	v, err := null.JSONOf[[]int]{}.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	v, err = null.JSONOf[[]int]{[]int{}}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`[]`), v)
+
+	// as do text and XML
+	b, err := null.JSONOf[[]int]{[]int{1, 2}}.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2]`, string(b))
+
+	assert.NoError(t, s.UnmarshalText([]byte(``)))
+	assert.True(t, s.IsZero())
+}
+
+func TestJSONOfHelpers(t *testing.T) {
+	var p Preferences
+	assert.NoError(t, p.Scan([]byte(`{"language":"eng"}`)))
+	assert.Equal(t, Preferences{Language: "eng"}, p)
+
+	v, err := p.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`{"language":"eng"}`), v)
+
+	assert.NoError(t, p.Scan(nil))
+	assert.Equal(t, Preferences{}, p)
+
+	v, err = p.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+}
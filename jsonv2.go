@@ -0,0 +1,801 @@
+//go:build goexperiment.jsonv2
+
+package null
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (i Int) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalIntTo(enc, i) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (i *Int) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalIntFrom(dec, i) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (i Int64) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalIntTo(enc, i) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (i *Int64) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalIntFrom(dec, i) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (u Uint) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalUintTo(enc, u) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (u *Uint) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalUintFrom(dec, u) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (u Uint64) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalUintTo(enc, u) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (u *Uint64) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalUintFrom(dec, u) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (f Float64) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalFloatTo(enc, f) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (f *Float64) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalFloatFrom(dec, f) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (f Float32) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalFloatTo(enc, f) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (f *Float32) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalFloatFrom(dec, f) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (b Bool) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalBoolTo(enc, b) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (b *Bool) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalBoolFrom(dec, b) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (s String) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalStringTo(enc, s) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (s *String) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalStringFrom(dec, s) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (i Int64String) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalIntAsStringTo(enc, i) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (i *Int64String) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return UnmarshalIntFromStringFrom(dec, i)
+}
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (t Time) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalTimeTo(enc, t) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (t *Time) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalTimeFrom(dec, t) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (t TimeUTC) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalTimeTo(enc, t) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (t *TimeUTC) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalTimeUTCFrom(dec, t) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (d Date) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalDateTo(enc, d) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (d *Date) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalDateFrom(dec, d) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (d Duration) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return MarshalDurationTo(enc, d, DurationISO8601)
+}
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (d *Duration) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return UnmarshalDurationFrom(dec, d)
+}
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (u UUID) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalUUIDTo(enc, u) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (u *UUID) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalUUIDFrom(dec, u) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (j JSON) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalJSONTo(enc, j) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (j *JSON) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalJSONFrom(dec, j) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (j CanonicalJSON) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalJSONTo(enc, JSON(j)) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (j *CanonicalJSON) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return UnmarshalJSONFrom(dec, (*JSON)(j))
+}
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (m Map[V]) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalMapTo(enc, m) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (m *Map[V]) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalMapFrom(dec, m) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (m MapOf[K, V]) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalMapOfTo(enc, m) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (m *MapOf[K, V]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return UnmarshalMapOfFrom(dec, m)
+}
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (s Slice[T]) MarshalJSONTo(enc *jsontext.Encoder) error { return MarshalSliceTo(enc, s) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (s *Slice[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error { return UnmarshalSliceFrom(dec, s) }
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (a IntArray[T]) MarshalJSONTo(enc *jsontext.Encoder) error { return marshalArrayTo(enc, a) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (a *IntArray[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalArrayFrom(dec, (*[]T)(a))
+}
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (a StringArray[T]) MarshalJSONTo(enc *jsontext.Encoder) error { return marshalArrayTo(enc, a) }
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (a *StringArray[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalArrayFrom(dec, (*[]T)(a))
+}
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (j JSONOf[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if j.IsNull() {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return jsonv2.MarshalEncode(enc, j.V)
+}
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (j *JSONOf[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	var u T
+
+	if dec.PeekKind() == jsontext.KindNull {
+		if err := dec.SkipValue(); err != nil {
+			return err
+		}
+	} else if err := jsonv2.UnmarshalDecode(dec, &u); err != nil {
+		return err
+	}
+
+	j.V = u
+	return nil
+}
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface
+func (v Value[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if v.IsNull() {
+		return enc.WriteToken(jsontext.Null)
+	}
+
+	// like MarshalJSON, ints and strings are written as is unless the type has its own JSON methods
+	if _, ok := any(v.V).(json.Marshaler); !ok {
+		rv := reflect.ValueOf(v.V)
+
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return enc.WriteToken(jsontext.Int(rv.Int()))
+		case reflect.String:
+			return enc.WriteToken(jsontext.String(rv.String()))
+		}
+	}
+
+	return jsonv2.MarshalEncode(enc, v.V)
+}
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface
+func (v *Value[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == jsontext.KindNull {
+		if err := dec.SkipValue(); err != nil {
+			return err
+		}
+		var zero T
+		v.V = zero
+		return nil
+	}
+
+	rv := reflect.ValueOf(&v.V).Elem()
+
+	// like UnmarshalJSON, numbers are range checked unless the type has its own JSON methods
+	if _, ok := any(&v.V).(json.Unmarshaler); !ok {
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			var i int64
+			if err := UnmarshalIntFrom(dec, &i); err != nil {
+				return withRangeType(err, rv)
+			}
+			return setReflectInt(rv, i)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			var u uint64
+			if err := UnmarshalUintFrom(dec, &u); err != nil {
+				return withRangeType(err, rv)
+			}
+			return setReflectUint(rv, u)
+		case reflect.Float32, reflect.Float64:
+			var f float64
+			if err := UnmarshalFloatFrom(dec, &f); err != nil {
+				return withRangeType(err, rv)
+			}
+			return setReflectFloat(rv, f)
+		}
+	}
+
+	return jsonv2.UnmarshalDecode(dec, &v.V)
+}
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface, writing null if the value is null or not set
+func (o Optional[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !o.Set || o.Null {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return jsonv2.MarshalEncode(enc, o.V)
+}
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface, marking the value as set even if it is null
+func (o *Optional[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	isNull := dec.PeekKind() == jsontext.KindNull
+
+	var v T
+	if err := jsonv2.UnmarshalDecode(dec, &v); err != nil {
+		return err
+	}
+
+	*o = Optional[T]{V: v, Set: true, Null: isNull}
+	return nil
+}
+
+// MarshalIntTo encodes an int type to a JSON stream, using null for zero.
+func MarshalIntTo[T constraints.Signed](enc *jsontext.Encoder, i T) error {
+	if i == 0 {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteToken(jsontext.Int(int64(i)))
+}
+
+// UnmarshalIntFrom decodes an int type from a JSON stream, using zero for null.
+func UnmarshalIntFrom[T constraints.Signed](dec *jsontext.Decoder, i *T) error {
+	switch dec.PeekKind() {
+	case jsontext.KindNull:
+		if err := dec.SkipValue(); err != nil {
+			return err
+		}
+		*i = 0
+		return nil
+	case jsontext.KindNumber:
+		tok, err := dec.ReadToken()
+		if err != nil {
+			return err
+		}
+		v, err := tok.Int()
+		if err != nil {
+			return err
+		}
+		return convertInt(v, i)
+	default:
+		return unexpectedJSONKind(dec, "int")
+	}
+}
+
+// MarshalUintTo encodes an unsigned int type to a JSON stream, using null for zero.
+func MarshalUintTo[T constraints.Unsigned](enc *jsontext.Encoder, u T) error {
+	if u == 0 {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteToken(jsontext.Uint(uint64(u)))
+}
+
+// UnmarshalUintFrom decodes an unsigned int type from a JSON stream, using zero for null.
+func UnmarshalUintFrom[T constraints.Unsigned](dec *jsontext.Decoder, u *T) error {
+	switch dec.PeekKind() {
+	case jsontext.KindNull:
+		if err := dec.SkipValue(); err != nil {
+			return err
+		}
+		*u = 0
+		return nil
+	case jsontext.KindNumber:
+		tok, err := dec.ReadToken()
+		if err != nil {
+			return err
+		}
+		v, err := tok.Uint()
+		if err != nil {
+			if s := tok.String(); isNegativeInt(s) {
+				return &RangeError{Value: s, Type: reflect.TypeOf(*u)}
+			}
+			return err
+		}
+		return convertUint(v, u)
+	default:
+		return unexpectedJSONKind(dec, "unsigned int")
+	}
+}
+
+// MarshalFloatTo encodes a float type to a JSON stream, using null for zero. Like MarshalFloat, NaN and ±Inf return
+// an error.
+func MarshalFloatTo[T constraints.Float](enc *jsontext.Encoder, f T) error {
+	if f == 0 {
+		return enc.WriteToken(jsontext.Null)
+	}
+	if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+		return fmt.Errorf("unable to marshal %v as JSON", f)
+	}
+	if floatBits(f) == 32 {
+		return enc.WriteToken(jsontext.Float32(float32(f)))
+	}
+	return enc.WriteToken(jsontext.Float(float64(f)))
+}
+
+// UnmarshalFloatFrom decodes a float type from a JSON stream, using zero for null.
+func UnmarshalFloatFrom[T constraints.Float](dec *jsontext.Decoder, f *T) error {
+	switch dec.PeekKind() {
+	case jsontext.KindNull:
+		if err := dec.SkipValue(); err != nil {
+			return err
+		}
+		*f = 0
+		return nil
+	case jsontext.KindNumber:
+		tok, err := dec.ReadToken()
+		if err != nil {
+			return err
+		}
+		v, err := tok.Float()
+		if err != nil {
+			return err
+		}
+		return convertFloat(v, f)
+	default:
+		return unexpectedJSONKind(dec, "float")
+	}
+}
+
+// MarshalBoolTo encodes a bool type to a JSON stream, using null for false.
+func MarshalBoolTo[T ~bool](enc *jsontext.Encoder, b T) error {
+	if !b {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteToken(jsontext.True)
+}
+
+// UnmarshalBoolFrom decodes a bool type from a JSON stream, using false for null.
+func UnmarshalBoolFrom[T ~bool](dec *jsontext.Decoder, b *T) error {
+	switch dec.PeekKind() {
+	case jsontext.KindNull, jsontext.KindFalse:
+		if err := dec.SkipValue(); err != nil {
+			return err
+		}
+		*b = false
+		return nil
+	case jsontext.KindTrue:
+		if err := dec.SkipValue(); err != nil {
+			return err
+		}
+		*b = true
+		return nil
+	default:
+		return unexpectedJSONKind(dec, "bool")
+	}
+}
+
+// MarshalStringTo encodes a string type to a JSON stream, using null for empty strings.
+func MarshalStringTo[T ~string](enc *jsontext.Encoder, s T) error {
+	if s == "" {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteToken(jsontext.String(string(s)))
+}
+
+// UnmarshalStringFrom decodes a string type from a JSON stream, using empty string for null.
+func UnmarshalStringFrom[T ~string](dec *jsontext.Decoder, s *T) error {
+	switch dec.PeekKind() {
+	case jsontext.KindNull:
+		if err := dec.SkipValue(); err != nil {
+			return err
+		}
+		*s = ""
+		return nil
+	case jsontext.KindString:
+		tok, err := dec.ReadToken()
+		if err != nil {
+			return err
+		}
+		*s = T(tok.String())
+		return nil
+	default:
+		return unexpectedJSONKind(dec, "string")
+	}
+}
+
+// MarshalIntAsStringTo encodes an int type to a JSON stream as a string, using null for zero.
+func MarshalIntAsStringTo[T constraints.Signed](enc *jsontext.Encoder, i T) error {
+	if i == 0 {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteToken(jsontext.String(strconv.FormatInt(int64(i), 10)))
+}
+
+// UnmarshalIntFromStringFrom decodes an int type from a JSON stream string or number, using zero for null.
+func UnmarshalIntFromStringFrom[T constraints.Signed](dec *jsontext.Decoder, i *T) error {
+	if dec.PeekKind() != jsontext.KindString {
+		return UnmarshalIntFrom(dec, i)
+	}
+
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return err
+	}
+
+	s := tok.String()
+	if !isJSONInt([]byte(s)) {
+		return fmt.Errorf("unable to unmarshal %q as int", s)
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return &RangeError{Value: s, Type: reflect.TypeOf(*i)}
+	}
+	return convertInt(v, i)
+}
+
+// MarshalTimeTo encodes a time type to a JSON stream, using null for the zero time.
+func MarshalTimeTo[T TimeType](enc *jsontext.Encoder, t T) error {
+	tm := struct{ time.Time }(t).Time
+	if tm.IsZero() {
+		return enc.WriteToken(jsontext.Null)
+	}
+
+	b, err := tm.MarshalText()
+	if err != nil {
+		return err
+	}
+	return enc.WriteToken(jsontext.String(string(b)))
+}
+
+// UnmarshalTimeFrom decodes a time type from a JSON stream, using the zero time for null.
+func UnmarshalTimeFrom[T TimeType](dec *jsontext.Decoder, t *T) error {
+	switch dec.PeekKind() {
+	case jsontext.KindNull:
+		if err := dec.SkipValue(); err != nil {
+			return err
+		}
+		*t = T{}
+		return nil
+	case jsontext.KindString:
+		tok, err := dec.ReadToken()
+		if err != nil {
+			return err
+		}
+		var tm time.Time
+		if err := tm.UnmarshalText([]byte(tok.String())); err != nil {
+			return err
+		}
+		*t = T{tm}
+		return nil
+	default:
+		return unexpectedJSONKind(dec, "time")
+	}
+}
+
+// UnmarshalTimeUTCFrom is like UnmarshalTimeFrom but normalizes the decoded value to UTC.
+func UnmarshalTimeUTCFrom[T TimeType](dec *jsontext.Decoder, t *T) error {
+	if err := UnmarshalTimeFrom(dec, t); err != nil {
+		return err
+	}
+
+	toUTC(t)
+	return nil
+}
+
+// MarshalDateTo encodes a date to a JSON stream as a full-date string, using null for the zero date.
+func MarshalDateTo(enc *jsontext.Encoder, d Date) error {
+	if d.IsNull() {
+		return enc.WriteToken(jsontext.Null)
+	}
+	if err := validateDate(d); err != nil {
+		return err
+	}
+	return enc.WriteToken(jsontext.String(d.String()))
+}
+
+// UnmarshalDateFrom decodes a date from a JSON stream full-date string, using the zero date for null.
+func UnmarshalDateFrom(dec *jsontext.Decoder, d *Date) error {
+	switch dec.PeekKind() {
+	case jsontext.KindNull:
+		if err := dec.SkipValue(); err != nil {
+			return err
+		}
+		*d = NullDate
+		return nil
+	case jsontext.KindString:
+		tok, err := dec.ReadToken()
+		if err != nil {
+			return err
+		}
+		parsed, err := ParseDate(tok.String())
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	default:
+		return unexpectedJSONKind(dec, "date")
+	}
+}
+
+// MarshalDurationTo encodes a duration type to a JSON stream in the given format, using null for zero.
+func MarshalDurationTo[T ~int64](enc *jsontext.Encoder, d T, format DurationFormat) error {
+	if d == 0 {
+		return enc.WriteToken(jsontext.Null)
+	}
+	if format == DurationGo {
+		return enc.WriteToken(jsontext.String(time.Duration(d).String()))
+	}
+	return enc.WriteToken(jsontext.String(formatISODuration(time.Duration(d))))
+}
+
+// UnmarshalDurationFrom decodes a duration type from a JSON stream, using zero for null. Like UnmarshalDuration,
+// strings can be ISO 8601 or Go duration strings, and numbers are taken as seconds.
+func UnmarshalDurationFrom[T ~int64](dec *jsontext.Decoder, d *T) error {
+	var dur time.Duration
+
+	switch dec.PeekKind() {
+	case jsontext.KindNull:
+		if err := dec.SkipValue(); err != nil {
+			return err
+		}
+	case jsontext.KindNumber, jsontext.KindString:
+		tok, err := dec.ReadToken()
+		if err != nil {
+			return err
+		}
+
+		s := tok.String()
+		switch {
+		case tok.Kind() == jsontext.KindNumber:
+			dur, err = scaleDuration(s, time.Second)
+		case isISODuration(s):
+			dur, err = parseISODuration(s)
+		default:
+			dur, err = time.ParseDuration(s)
+		}
+
+		if err != nil {
+			if tok.Kind() == jsontext.KindString {
+				s = strconv.Quote(s)
+			}
+			return fmt.Errorf("unable to unmarshal %s as duration: %w", s, err)
+		}
+	default:
+		return unexpectedJSONKind(dec, "duration")
+	}
+
+	*d = T(dur)
+	return nil
+}
+
+// MarshalUUIDTo encodes a UUID type to a JSON stream, using null for the zero UUID.
+func MarshalUUIDTo[T ~[16]byte](enc *jsontext.Encoder, u T) error {
+	if u == (T{}) {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteToken(jsontext.String(UUID(u).String()))
+}
+
+// UnmarshalUUIDFrom decodes a UUID type from a JSON stream, using the zero UUID for null.
+func UnmarshalUUIDFrom[T ~[16]byte](dec *jsontext.Decoder, u *T) error {
+	switch dec.PeekKind() {
+	case jsontext.KindNull:
+		if err := dec.SkipValue(); err != nil {
+			return err
+		}
+		*u = T{}
+		return nil
+	case jsontext.KindString:
+		tok, err := dec.ReadToken()
+		if err != nil {
+			return err
+		}
+		parsed, err := ParseUUID(tok.String())
+		if err != nil {
+			return err
+		}
+		*u = T(parsed)
+		return nil
+	default:
+		return unexpectedJSONKind(dec, "UUID")
+	}
+}
+
+// MarshalJSONTo encodes JSON to a JSON stream, using null for empty JSON.
+func MarshalJSONTo(enc *jsontext.Encoder, j JSON) error {
+	if len(j) == 0 {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteValue(jsontext.Value(j))
+}
+
+// UnmarshalJSONFrom decodes the next value in a JSON stream as JSON.
+func UnmarshalJSONFrom(dec *jsontext.Decoder, j *JSON) error {
+	v, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+
+	*j = JSON(v.Clone())
+	return nil
+}
+
+// MarshalMapTo encodes a map to a JSON stream, using null for an empty map.
+func MarshalMapTo[V any](enc *jsontext.Encoder, m Map[V]) error {
+	return MarshalMapOfTo(enc, MapOf[string, V](m))
+}
+
+// UnmarshalMapFrom decodes a new map from a JSON stream, using an empty map for null.
+func UnmarshalMapFrom[V any](dec *jsontext.Decoder, m *Map[V]) error {
+	return UnmarshalMapOfFrom(dec, (*MapOf[string, V])(m))
+}
+
+// MarshalMapOfTo encodes a map to a JSON stream, using null for an empty map.
+func MarshalMapOfTo[K MapKey, V any](enc *jsontext.Encoder, m MapOf[K, V]) error {
+	if len(m) == 0 {
+		return enc.WriteToken(jsontext.Null)
+	}
+
+	// keys of plain string types can be encoded directly, but others are converted to strings so that they're handled
+	// the same as by MarshalMapOf, rather than json/v2 using our types' MarshalJSONTo methods which don't always write
+	// strings
+	var k K
+	if _, ok := any(k).(encoding.TextMarshaler); !ok && isStringKey[K]() {
+		return jsonv2.MarshalEncode(enc, map[K]V(m))
+	}
+
+	raw, err := stringMapKeys(m)
+	if err != nil {
+		return err
+	}
+	return jsonv2.MarshalEncode(enc, raw)
+}
+
+// UnmarshalMapOfFrom decodes a new map from a JSON stream, using an empty map for null. Like UnmarshalMapOf, any
+// existing entries in the map are discarded.
+func UnmarshalMapOfFrom[K MapKey, V any](dec *jsontext.Decoder, m *MapOf[K, V]) error {
+	if isStringKey[K]() {
+		var u map[K]V
+		if err := jsonv2.UnmarshalDecode(dec, &u); err != nil {
+			return err
+		}
+		*m = initMap(u)
+		return nil
+	}
+
+	// int keys are decoded as strings and converted so that they're handled the same as by UnmarshalMapOf
+	var raw map[string]V
+	if err := jsonv2.UnmarshalDecode(dec, &raw); err != nil {
+		return err
+	}
+
+	unmarshaled, err := convertMapKeys[K](raw)
+	if err != nil {
+		return err
+	}
+
+	*m = unmarshaled
+	return nil
+}
+
+// converts a map to a new map with string keys
+func stringMapKeys[K MapKey, V any](m MapOf[K, V]) (map[string]V, error) {
+	raw := make(map[string]V, len(m))
+	for k, v := range m {
+		s, err := marshalMapKey(k)
+		if err != nil {
+			return nil, err
+		}
+		raw[s] = v
+	}
+	return raw, nil
+}
+
+// marshals a JSON object key in the same way as encoding/json, i.e. using MarshalText if the key type has it and
+// isn't a string type
+func marshalMapKey[K MapKey](k K) (string, error) {
+	rv := reflect.ValueOf(k)
+
+	if rv.Kind() == reflect.String {
+		return rv.String(), nil
+	}
+
+	if m, ok := any(k).(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	default:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	}
+}
+
+// MarshalSliceTo encodes a slice to a JSON stream, using null for an empty slice.
+func MarshalSliceTo[T any](enc *jsontext.Encoder, s Slice[T]) error {
+	if len(s) == 0 {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return jsonv2.MarshalEncode(enc, []T(s))
+}
+
+// UnmarshalSliceFrom decodes a new slice from a JSON stream, using an empty slice for null.
+func UnmarshalSliceFrom[T any](dec *jsontext.Decoder, s *Slice[T]) error {
+	var u []T
+	if err := jsonv2.UnmarshalDecode(dec, &u); err != nil {
+		return err
+	}
+
+	if u == nil {
+		u = make([]T, 0) // initialize empty slice
+	}
+
+	*s = u
+	return nil
+}
+
+// encodes an array to a JSON stream as an array, or null if it's nil
+func marshalArrayTo[T any](enc *jsontext.Encoder, a []T) error {
+	if a == nil {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return jsonv2.MarshalEncode(enc, a)
+}
+
+// decodes a JSON array from a JSON stream into a new slice, using an empty slice for null
+func unmarshalArrayFrom[T any](dec *jsontext.Decoder, a *[]T) error {
+	var u []T
+	if err := jsonv2.UnmarshalDecode(dec, &u); err != nil {
+		return err
+	}
+	if u == nil {
+		u = []T{}
+	}
+
+	*a = u
+	return nil
+}
+
+// skips the next value in the stream and returns an error describing its unexpected kind
+func unexpectedJSONKind(dec *jsontext.Decoder, target string) error {
+	kind := dec.PeekKind()
+
+	if err := dec.SkipValue(); err != nil {
+		return err
+	}
+
+	name := kind.String()
+	switch kind {
+	case jsontext.KindBeginObject:
+		name = "object"
+	case jsontext.KindBeginArray:
+		name = "array"
+	}
+	return fmt.Errorf("unable to unmarshal JSON %s as %s", name, target)
+}
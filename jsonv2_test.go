@@ -0,0 +1,238 @@
+//go:build goexperiment.jsonv2
+
+package null_test
+
+import (
+	"encoding/json"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type CustomV2ID int32
+
+func (i CustomV2ID) MarshalJSON() ([]byte, error)  { return null.MarshalInt(i) }
+func (i *CustomV2ID) UnmarshalJSON(b []byte) error { return null.UnmarshalInt(b, i) }
+func (i CustomV2ID) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return null.MarshalIntTo(enc, i)
+}
+func (i *CustomV2ID) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return null.UnmarshalIntFrom(dec, i)
+}
+
+func TestJSONv2(t *testing.T) {
+	type payload struct {
+		Int     null.Int     `json:"int"`
+		Int64   null.Int64   `json:"int64"`
+		Uint    null.Uint    `json:"uint"`
+		Uint64  null.Uint64  `json:"uint64"`
+		Float64 null.Float64 `json:"float64"`
+		Float32 null.Float32 `json:"float32"`
+		Bool    null.Bool    `json:"bool"`
+		String  null.String  `json:"string"`
+		Custom  CustomV2ID   `json:"custom"`
+	}
+
+	tcs := []struct {
+		value     payload
+		marshaled string
+	}{
+		{
+			payload{},
+			`{"int":null,"int64":null,"uint":null,"uint64":null,"float64":null,"float32":null,"bool":null,"string":null,"custom":null}`,
+		},
+		{
+			payload{1, math.MinInt64, 3, math.MaxUint64, 1.5e-7, 0.1, true, "foo \"bar\" é \n", -5},
+			`{"int":1,"int64":-9223372036854775808,"uint":3,"uint64":18446744073709551615,"float64":1.5e-7,"float32":0.1,"bool":true,"string":"foo \"bar\" é \n","custom":-5}`,
+		},
+	}
+
+	for _, tc := range tcs {
+		v1, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, string(v1), "v1 marshaled mismatch for %v", tc.value)
+
+		v2, err := jsonv2.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, string(v2), "v2 marshaled mismatch for %v", tc.value)
+
+		// unmarshal into non-zero values to check nulls are applied
+		unmarshaledV1 := payload{1, 2, 3, 4, 5, 6, true, "x", 7}
+		err = json.Unmarshal(v1, &unmarshaledV1)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, unmarshaledV1, "v1 unmarshaled mismatch for %v", tc.value)
+
+		unmarshaledV2 := payload{1, 2, 3, 4, 5, 6, true, "x", 7}
+		err = jsonv2.Unmarshal(v2, &unmarshaledV2)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, unmarshaledV2, "v2 unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+func TestJSONv2Errors(t *testing.T) {
+	var i CustomV2ID
+	assert.ErrorContains(t, jsonv2.Unmarshal([]byte(`4294967296`), &i), "value 4294967296 is out of range for null_test.CustomV2ID")
+	assert.ErrorContains(t, jsonv2.Unmarshal([]byte(`"1"`), &i), "unable to unmarshal JSON string as int")
+	assert.Error(t, jsonv2.Unmarshal([]byte(`1.5`), &i))
+
+	var u null.Uint
+	assert.Error(t, jsonv2.Unmarshal([]byte(`-1`), &u))
+
+	var f null.Float64
+	assert.ErrorContains(t, jsonv2.Unmarshal([]byte(`true`), &f), "unable to unmarshal JSON true as float")
+	_, err := jsonv2.Marshal(null.Float64(math.NaN()))
+	assert.Error(t, err)
+
+	var b null.Bool
+	assert.ErrorContains(t, jsonv2.Unmarshal([]byte(`1`), &b), "unable to unmarshal JSON number as bool")
+
+	var s null.String
+	assert.ErrorContains(t, jsonv2.Unmarshal([]byte(`{}`), &s), "unable to unmarshal JSON object as string")
+}
+
+func TestJSONv2MapKeys(t *testing.T) {
+	// with json/v2, plain maps with our int types as keys can be unmarshaled as well as marshaled
+	m := map[null.Int64]string{1: "one", 2: "two"}
+
+	b, err := json.Marshal(m)
+	assert.NoError(t, err)
+
+	var unmarshaled map[null.Int64]string
+	assert.NoError(t, json.Unmarshal(b, &unmarshaled))
+	assert.Equal(t, m, unmarshaled)
+}
+
+func TestJSONv2Types(t *testing.T) {
+	type payload struct {
+		Int64String null.Int64String               `json:"int64string"`
+		Time        null.Time                      `json:"time"`
+		TimeUTC     null.TimeUTC                   `json:"timeutc"`
+		Date        null.Date                      `json:"date"`
+		Duration    null.Duration                  `json:"duration"`
+		UUID        null.UUID                      `json:"uuid"`
+		JSON        null.JSON                      `json:"json"`
+		Canonical   null.CanonicalJSON             `json:"canonical"`
+		JSONOf      null.JSONOf[coords]            `json:"jsonof"`
+		Map         null.Map[int]                  `json:"map"`
+		MapOf       null.MapOf[null.Int64, string] `json:"mapof"`
+		Slice       null.Slice[int]                `json:"slice"`
+		Value       null.Value[ContactID]          `json:"value"`
+		Name        null.Value[ContactName]        `json:"name"`
+		Optional    null.Optional[int]             `json:"optional"`
+		Ints        null.IntArray[int32]           `json:"ints"`
+		Strings     null.StringArray[string]       `json:"strings"`
+	}
+
+	t1 := time.Date(2026, 10, 17, 13, 30, 15, 123456000, time.UTC)
+	u1 := null.UUID{0x9b, 0x7b, 0x6b, 0x8a, 0x8e, 0x3c, 0x4f, 0x9a, 0x9a, 0x43, 0x1d, 0x8b, 0x5a, 0x1f, 0x2c, 0x3d}
+
+	full := payload{
+		Int64String: 123,
+		Time:        null.Time{Time: t1},
+		TimeUTC:     null.TimeUTC{Time: t1},
+		Date:        null.Date{Year: 2026, Month: 10, Day: 17},
+		Duration:    null.Duration(90 * time.Minute),
+		UUID:        u1,
+		JSON:        null.JSON(`{"foo":[1,2]}`),
+		Canonical:   null.CanonicalJSON(`{"b":1,"a":2}`),
+		JSONOf:      null.JSONOf[coords]{V: coords{1.5, 2.5}},
+		Map:         null.Map[int]{"foo": 1},
+		MapOf:       null.MapOf[null.Int64, string]{1: "one"},
+		Slice:       null.Slice[int]{1, 2},
+		Value:       null.Value[ContactID]{V: 123},
+		Name:        null.Value[ContactName]{V: "Bob"},
+		Optional:    null.Optional[int]{V: 0, Set: true},
+		Ints:        null.IntArray[int32]{1, 2},
+		Strings:     null.StringArray[string]{"a", "b"},
+	}
+
+	tcs := []struct {
+		value       payload
+		marshaled   string
+		unmarshaled payload
+	}{
+		{
+			payload{},
+			`{"int64string":null,"time":null,"timeutc":null,"date":null,"duration":null,"uuid":null,"json":null,"canonical":null,"jsonof":null,"map":null,"mapof":null,"slice":null,"value":null,"name":null,"optional":null,"ints":null,"strings":null}`,
+			payload{
+				JSON:      null.JSON(`null`),
+				Canonical: null.CanonicalJSON(`null`),
+				Map:       null.Map[int]{},
+				MapOf:     null.MapOf[null.Int64, string]{},
+				Slice:     null.Slice[int]{},
+				Optional:  null.Optional[int]{Set: true, Null: true},
+				Ints:      null.IntArray[int32]{},
+				Strings:   null.StringArray[string]{},
+			},
+		},
+		{
+			full,
+			`{"int64string":"123","time":"2026-10-17T13:30:15.123456Z","timeutc":"2026-10-17T13:30:15.123456Z","date":"2026-10-17","duration":"PT1H30M","uuid":"9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d","json":{"foo":[1,2]},"canonical":{"b":1,"a":2},"jsonof":{"Lat":1.5,"Lng":2.5},"map":{"foo":1},"mapof":{"1":"one"},"slice":[1,2],"value":123,"name":"Bob","optional":0,"ints":[1,2],"strings":["a","b"]}`,
+			full,
+		},
+	}
+
+	for _, tc := range tcs {
+		v1, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, string(v1), "v1 marshaled mismatch for %v", tc.value)
+
+		v2, err := jsonv2.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, string(v2), "v2 marshaled mismatch for %v", tc.value)
+
+		// unmarshal into non-zero values to check nulls are applied
+		unmarshaledV1 := full
+		err = json.Unmarshal(v1, &unmarshaledV1)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.unmarshaled, unmarshaledV1, "v1 unmarshaled mismatch for %v", tc.value)
+
+		unmarshaledV2 := full
+		err = jsonv2.Unmarshal(v2, &unmarshaledV2)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.unmarshaled, unmarshaledV2, "v2 unmarshaled mismatch for %v", tc.value)
+	}
+
+	// other accepted inputs
+	var p payload
+	err := jsonv2.Unmarshal([]byte(`{"int64string":-5,"timeutc":"2026-10-17T08:30:15-05:00","duration":5400}`), &p)
+	assert.NoError(t, err)
+	assert.Equal(t, null.Int64String(-5), p.Int64String)
+	assert.Equal(t, null.TimeUTC{Time: time.Date(2026, 10, 17, 13, 30, 15, 0, time.UTC)}, p.TimeUTC)
+	assert.Equal(t, null.Duration(90*time.Minute), p.Duration)
+}
+
+func TestJSONv2TypeErrors(t *testing.T) {
+	var i null.Int64String
+	assert.ErrorContains(t, jsonv2.Unmarshal([]byte(`"1.5"`), &i), `unable to unmarshal "1.5" as int`)
+	assert.ErrorContains(t, jsonv2.Unmarshal([]byte(`"9223372036854775808"`), &i), "value 9223372036854775808 is out of range for null.Int64String")
+
+	var tm null.Time
+	assert.ErrorContains(t, jsonv2.Unmarshal([]byte(`123`), &tm), "unable to unmarshal JSON number as time")
+	assert.Error(t, jsonv2.Unmarshal([]byte(`"yesterday"`), &tm))
+
+	var d null.Date
+	assert.ErrorContains(t, jsonv2.Unmarshal([]byte(`[]`), &d), "unable to unmarshal JSON array as date")
+	assert.Error(t, jsonv2.Unmarshal([]byte(`"2026-13-01"`), &d))
+
+	var dur null.Duration
+	assert.ErrorContains(t, jsonv2.Unmarshal([]byte(`"1 hour"`), &dur), `unable to unmarshal "1 hour" as duration`)
+	assert.ErrorContains(t, jsonv2.Unmarshal([]byte(`true`), &dur), "unable to unmarshal JSON true as duration")
+
+	var u null.UUID
+	assert.ErrorContains(t, jsonv2.Unmarshal([]byte(`"foo"`), &u), `invalid UUID "foo"`)
+
+	var m null.MapOf[null.Int64, string]
+	assert.Error(t, jsonv2.Unmarshal([]byte(`{"x":"one"}`), &m))
+
+	var v null.Value[uint8]
+	assert.ErrorContains(t, jsonv2.Unmarshal([]byte(`256`), &v), "value 256 is out of range for uint8")
+
+	var j null.JSON
+	assert.Error(t, jsonv2.Unmarshal([]byte(`{"foo":`), &j))
+}
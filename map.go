@@ -2,13 +2,22 @@ package null
 
 import (
 	"database/sql/driver"
+	"encoding"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"reflect"
+	"strconv"
+
+	"golang.org/x/exp/constraints"
 )
 
 // Map is a generic map which is written to the database as JSON.
 type Map[V any] map[string]V
 
+// IsZero returns whether this is the zero value
+func (m Map[V]) IsZero() bool { return len(m) == 0 }
+
 // Scan implements the Scanner interface
 func (m *Map[V]) Scan(value any) error { return ScanMap(value, m) }
 
@@ -21,12 +30,215 @@ func (m *Map[V]) UnmarshalJSON(data []byte) error { return UnmarshalMap(data, m)
 // MarshalJSON implements the Marshaller interface
 func (m Map[V]) MarshalJSON() ([]byte, error) { return MarshalMap(m) }
 
-// ScanMap scans a nullable text or JSON into a map, using an empty map for NULL.
-func ScanMap[V any](value any, m *Map[V]) error {
-	if value == nil {
-		*m = make(Map[V])
+// UnmarshalText implements the TextUnmarshaler interface
+func (m *Map[V]) UnmarshalText(b []byte) error { return UnmarshalMapText(b, m) }
+
+// MarshalText implements the TextMarshaler interface
+func (m Map[V]) MarshalText() ([]byte, error) { return MarshalMapText(m) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (m *Map[V]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(m, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (m Map[V]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(m, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (m Map[V]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(m, name) }
+
+// MergePatch returns a new map which is the result of applying the given RFC 7386 merge patch to this map.
+func (m Map[V]) MergePatch(patch JSON) (Map[V], error) {
+	patched, err := MapOf[string, V](m).MergePatch(patch)
+	return Map[V](patched), err
+}
+
+// MapKey is the constraint for the key types of a MapOf, i.e. the string and int types that encoding/json supports
+// as object keys.
+type MapKey interface {
+	~string | constraints.Integer
+}
+
+// MapOf is a generic map with a custom key type which is written to the database as JSON. Map[V] is equivalent to
+// MapOf[string, V].
+type MapOf[K MapKey, V any] map[K]V
+
+// IsZero returns whether this is the zero value
+func (m MapOf[K, V]) IsZero() bool { return len(m) == 0 }
+
+// Scan implements the Scanner interface
+func (m *MapOf[K, V]) Scan(value any) error { return ScanMapOf(value, m) }
+
+// Value implements the Valuer interface
+func (m MapOf[K, V]) Value() (driver.Value, error) { return MapOfValue(m) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (m *MapOf[K, V]) UnmarshalJSON(data []byte) error { return UnmarshalMapOf(data, m) }
+
+// MarshalJSON implements the Marshaller interface
+func (m MapOf[K, V]) MarshalJSON() ([]byte, error) { return MarshalMapOf(m) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (m *MapOf[K, V]) UnmarshalText(b []byte) error { return UnmarshalMapOfText(b, m) }
+
+// MarshalText implements the TextMarshaler interface
+func (m MapOf[K, V]) MarshalText() ([]byte, error) { return MarshalMapOfText(m) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (m *MapOf[K, V]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(m, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (m MapOf[K, V]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(m, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (m MapOf[K, V]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(m, name) }
+
+// MergePatch returns a new map which is the result of applying the given RFC 7386 merge patch to this map.
+func (m MapOf[K, V]) MergePatch(patch JSON) (MapOf[K, V], error) {
+	var j JSON
+	if len(m) > 0 {
+		var err error
+		if j, err = json.Marshal(map[K]V(m)); err != nil {
+			return nil, err
+		}
+	}
+
+	patched, err := j.MergePatch(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalMap[K, V](patched)
+}
+
+// ScanMap scans a nullable text or JSON into a new map, using an empty map for NULL. Any existing entries in the map are
+// discarded, use ScanMapMerge if you want to keep them.
+func ScanMap[V any](value any, m *Map[V]) error { return ScanMapOf(value, (*MapOf[string, V])(m)) }
+
+// ScanMapMerge scans a nullable text or JSON into an existing map, adding its entries to those already in the map and
+// replacing any with the same keys. NULL leaves the map unchanged.
+func ScanMapMerge[V any](value any, m *Map[V]) error {
+	return ScanMapOfMerge(value, (*MapOf[string, V])(m))
+}
+
+// MapValue converts a map to NULL if it is empty.
+func MapValue[V any](m Map[V]) (driver.Value, error) { return MapOfValue(MapOf[string, V](m)) }
+
+// MarshalMap marshals a map, returning null for an empty map.
+func MarshalMap[V any](m Map[V]) ([]byte, error) { return MarshalMapOf(MapOf[string, V](m)) }
+
+// UnmarshalMap unmarshals a new map from JSON, using an empty map for null. Any existing entries in the map are
+// discarded, use UnmarshalMapMerge if you want to keep them.
+func UnmarshalMap[V any](data []byte, m *Map[V]) error {
+	return UnmarshalMapOf(data, (*MapOf[string, V])(m))
+}
+
+// UnmarshalMapMerge unmarshals JSON into an existing map, adding its entries to those already in the map and replacing
+// any with the same keys. Null leaves the map unchanged.
+func UnmarshalMapMerge[V any](data []byte, m *Map[V]) error {
+	return UnmarshalMapOfMerge(data, (*MapOf[string, V])(m))
+}
+
+// UnmarshalMapText unmarshals a map from JSON text, using an empty map for empty text.
+func UnmarshalMapText[V any](data []byte, m *Map[V]) error {
+	return UnmarshalMapOfText(data, (*MapOf[string, V])(m))
+}
+
+// MarshalMapText marshals a map to JSON text, using empty text for an empty map.
+func MarshalMapText[V any](m Map[V]) ([]byte, error) { return MarshalMapOfText(MapOf[string, V](m)) }
+
+// ScanMapOf scans a nullable text or JSON into a new map, using an empty map for NULL. Any existing entries in the map
+// are discarded, use ScanMapOfMerge if you want to keep them.
+func ScanMapOf[K MapKey, V any](value any, m *MapOf[K, V]) error {
+	scanned, err := scanMap[K, V](value)
+	if err != nil {
+		return err
+	}
+
+	*m = scanned
+	return nil
+}
+
+// ScanMapOfMerge scans a nullable text or JSON into an existing map, adding its entries to those already in the map
+// and replacing any with the same keys. NULL leaves the map unchanged.
+func ScanMapOfMerge[K MapKey, V any](value any, m *MapOf[K, V]) error {
+	scanned, err := scanMap[K, V](value)
+	if err != nil {
+		return err
+	}
+
+	mergeMap(m, scanned)
+	return nil
+}
+
+// MapOfValue converts a map to NULL if it is empty.
+func MapOfValue[K MapKey, V any](m MapOf[K, V]) (driver.Value, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(map[K]V(m))
+}
+
+// MarshalMapOf marshals a map, returning null for an empty map.
+func MarshalMapOf[K MapKey, V any](m MapOf[K, V]) ([]byte, error) {
+	if len(m) == 0 {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(map[K]V(m))
+}
+
+// UnmarshalMapOf unmarshals a new map from JSON, using an empty map for null. Any existing entries in the map are
+// discarded, use UnmarshalMapOfMerge if you want to keep them.
+func UnmarshalMapOf[K MapKey, V any](data []byte, m *MapOf[K, V]) error {
+	unmarshaled, err := unmarshalMap[K, V](data)
+	if err != nil {
+		return err
+	}
+
+	*m = unmarshaled
+	return nil
+}
+
+// UnmarshalMapOfMerge unmarshals JSON into an existing map, adding its entries to those already in the map and
+// replacing any with the same keys. Null leaves the map unchanged.
+func UnmarshalMapOfMerge[K MapKey, V any](data []byte, m *MapOf[K, V]) error {
+	unmarshaled, err := unmarshalMap[K, V](data)
+	if err != nil {
+		return err
+	}
+
+	mergeMap(m, unmarshaled)
+	return nil
+}
+
+// UnmarshalMapOfText unmarshals a map from JSON text, using an empty map for empty text.
+func UnmarshalMapOfText[K MapKey, V any](data []byte, m *MapOf[K, V]) error {
+	if len(data) == 0 {
+		*m = make(MapOf[K, V])
 		return nil
 	}
+	return UnmarshalMapOf(data, m)
+}
+
+// MarshalMapOfText marshals a map to JSON text, using empty text for an empty map.
+func MarshalMapOfText[K MapKey, V any](m MapOf[K, V]) ([]byte, error) {
+	if len(m) == 0 {
+		return []byte{}, nil
+	}
+	return MarshalMapOf(m)
+}
+
+// scans a nullable text or JSON into a new map, using an empty map for NULL
+func scanMap[K MapKey, V any](value any) (MapOf[K, V], error) {
+	if value == nil {
+		return make(MapOf[K, V]), nil
+	}
 
 	var raw []byte
 	switch typed := value.(type) {
@@ -35,46 +247,100 @@ func ScanMap[V any](value any, m *Map[V]) error {
 	case []byte:
 		raw = typed
 	default:
-		return fmt.Errorf("unable to scan %T as map", value)
+		return nil, fmt.Errorf("unable to scan %T as map", value)
 	}
 
 	// empty bytes is same as nil
 	if len(raw) == 0 {
-		*m = make(Map[V])
-		return nil
+		return make(MapOf[K, V]), nil
 	}
 
-	if err := json.Unmarshal(raw, m); err != nil {
-		return err
+	return unmarshalMap[K, V](raw)
+}
+
+// unmarshals a new map from JSON, using an empty map for null
+func unmarshalMap[K MapKey, V any](data []byte) (MapOf[K, V], error) {
+	if isStringKey[K]() {
+		var u map[K]V
+		if err := json.Unmarshal(data, &u); err != nil {
+			return nil, err
+		}
+		return initMap(u), nil
 	}
 
-	return nil
+	// we decode int keys ourselves because encoding/json (v1) passes quoted keys to UnmarshalJSON when the key type
+	// also implements TextUnmarshaler, and our int types reject quoted numbers
+	var raw map[string]V
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	return convertMapKeys[K](raw)
 }
 
-// MapValue converts a map to NULL if it is empty.
-func MapValue[V any](m Map[V]) (driver.Value, error) {
-	if len(m) == 0 {
-		return nil, nil
+// returns whether the key type is a string type, in which case maps can be decoded directly
+func isStringKey[K MapKey]() bool {
+	var k K
+	return reflect.TypeOf(k).Kind() == reflect.String
+}
+
+// returns the given map, or a new empty map if it is nil
+func initMap[K MapKey, V any](m map[K]V) MapOf[K, V] {
+	if m == nil {
+		return make(MapOf[K, V])
 	}
-	return json.Marshal(m)
+	return m
 }
 
-// MarshalMap marshals a map, returning null for an empty map.
-func MarshalMap[V any](m Map[V]) ([]byte, error) {
-	if len(m) == 0 {
-		return json.Marshal(nil)
+// converts a map decoded with string keys to a new map with the given int key type
+func convertMapKeys[K MapKey, V any](raw map[string]V) (MapOf[K, V], error) {
+	u := make(MapOf[K, V], len(raw))
+	for s, v := range raw {
+		k, err := unmarshalMapKey[K](s)
+		if err != nil {
+			return nil, err
+		}
+		u[k] = v
 	}
-	return json.Marshal(map[string]V(m))
+	return u, nil
 }
 
-func UnmarshalMap[V any](data []byte, m *Map[V]) error {
-	err := json.Unmarshal(data, (*map[string]V)(m))
-	if err != nil {
-		return err
+// unmarshals a JSON object key in the same way as encoding/json, i.e. using UnmarshalText if the key type has it
+func unmarshalMapKey[K MapKey](s string) (K, error) {
+	var k K
+
+	if u, ok := any(&k).(encoding.TextUnmarshaler); ok {
+		err := u.UnmarshalText([]byte(s))
+		return k, err
 	}
 
-	if *m == nil {
-		*m = make(Map[V]) // initialize empty map
+	rv := reflect.ValueOf(&k).Elem()
+
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return k, fmt.Errorf("unable to unmarshal map key %q as %s", s, rv.Type())
+		}
+		return k, setReflectInt(rv, i)
+	default:
+		u, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return k, fmt.Errorf("unable to unmarshal map key %q as %s", s, rv.Type())
+		}
+		return k, setReflectUint(rv, u)
+	}
+	return k, nil
+}
+
+// copies the entries of src into dst, initializing dst if it is nil
+func mergeMap[K MapKey, V any](dst *MapOf[K, V], src MapOf[K, V]) {
+	if *dst == nil {
+		*dst = make(MapOf[K, V], len(src))
+	}
+	for k, v := range src {
+		(*dst)[k] = v
 	}
-	return nil
 }
@@ -70,3 +70,216 @@ func TestMap(t *testing.T) {
 	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value jsonb null);`)
 	testMap()
 }
+
+func TestMapScanRows(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(id int, value jsonb null);`)
+	mustExec(db, `INSERT INTO test(id, value) VALUES(1, '{"a": "1", "b": "2"}'), (2, '{"c": "3"}'), (3, NULL), (4, '{"a": "4"}')`)
+
+	// scanning into the same map should replace its contents each time
+	rows, err := db.Query(`SELECT value FROM test ORDER BY id`)
+	assert.NoError(t, err)
+
+	var m null.Map[string]
+	var scanned []null.Map[string]
+	for rows.Next() {
+		assert.NoError(t, rows.Scan(&m))
+		scanned = append(scanned, m)
+	}
+
+	assert.Equal(t, []null.Map[string]{{"a": "1", "b": "2"}, {"c": "3"}, {}, {"a": "4"}}, scanned)
+
+	// unless we explicitly merge
+	rows, err = db.Query(`SELECT value FROM test ORDER BY id`)
+	assert.NoError(t, err)
+
+	var merged null.Map[string]
+	for rows.Next() {
+		var raw []byte
+		assert.NoError(t, rows.Scan(&raw))
+		assert.NoError(t, null.ScanMapMerge(raw, &merged))
+	}
+
+	assert.Equal(t, null.Map[string]{"a": "4", "b": "2", "c": "3"}, merged)
+}
+
+func TestMapMerge(t *testing.T) {
+	m := null.Map[int]{"a": 1, "b": 2}
+	prev := m
+
+	// scanning and unmarshaling replace the map without modifying the previous one
+	assert.NoError(t, m.Scan([]byte(`{"c": 3}`)))
+	assert.Equal(t, null.Map[int]{"c": 3}, m)
+	assert.Equal(t, null.Map[int]{"a": 1, "b": 2}, prev)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"d": 4}`), &m))
+	assert.Equal(t, null.Map[int]{"d": 4}, m)
+
+	// errors leave the map unchanged
+	assert.Error(t, m.Scan([]byte(`{"e": "x"}`)))
+	assert.Equal(t, null.Map[int]{"d": 4}, m)
+
+	// merging adds entries, replacing any with the same key
+	assert.NoError(t, null.ScanMapMerge([]byte(`{"d": 5, "e": 6}`), &m))
+	assert.Equal(t, null.Map[int]{"d": 5, "e": 6}, m)
+
+	assert.NoError(t, null.UnmarshalMapMerge([]byte(`{"f": 7}`), &m))
+	assert.Equal(t, null.Map[int]{"d": 5, "e": 6, "f": 7}, m)
+
+	// NULL and null leave the map unchanged
+	assert.NoError(t, null.ScanMapMerge(nil, &m))
+	assert.NoError(t, null.UnmarshalMapMerge([]byte(`null`), &m))
+	assert.Equal(t, null.Map[int]{"d": 5, "e": 6, "f": 7}, m)
+
+	// and a nil map is initialized
+	var n null.Map[int]
+	assert.NoError(t, null.UnmarshalMapMerge([]byte(`null`), &n))
+	assert.Equal(t, null.Map[int]{}, n)
+	assert.NoError(t, null.ScanMapMerge(`{"a": 1}`, &n))
+	assert.Equal(t, null.Map[int]{"a": 1}, n)
+}
+
+func TestMapOf(t *testing.T) {
+	db := getTestDB()
+
+	testMapOf := func() {
+		tcs := []struct {
+			value     null.MapOf[Lang, string]
+			dbValue   driver.Value
+			marshaled []byte
+		}{
+			{null.MapOf[Lang, string]{"eng": "Hello", "spa": "Hola"}, []byte(`{"eng":"Hello","spa":"Hola"}`), []byte(`{"eng":"Hello","spa":"Hola"}`)},
+			{null.MapOf[Lang, string]{}, nil, []byte(`null`)},
+			{null.MapOf[Lang, string](nil), nil, []byte(`null`)},
+		}
+
+		for _, tc := range tcs {
+			mustExec(db, `DELETE FROM test`)
+
+			dbValue, err := tc.value.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+			// check writing the value to the database
+			_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+			assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+			rows, err := db.Query(`SELECT value FROM test;`)
+			assert.NoError(t, err)
+
+			scanned := null.MapOf[Lang, string]{}
+			assert.True(t, rows.Next())
+			err = rows.Scan(&scanned)
+			assert.NoError(t, err)
+
+			// we never return a nil map even if that's what we wrote
+			expected := tc.value
+			if expected == nil {
+				expected = null.MapOf[Lang, string]{}
+			}
+
+			assert.Equal(t, expected, scanned, "scanned value mismatch for %v", tc.value)
+
+			marshaled, err := json.Marshal(tc.value)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+			unmarshaled := null.MapOf[Lang, string]{}
+			err = json.Unmarshal(marshaled, &unmarshaled)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+		}
+	}
+
+	// test with TEXT column
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value text null);`)
+	testMapOf()
+
+	// test with JSONB column
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value jsonb null);`)
+	testMapOf()
+}
+
+func TestMapOfIntKeys(t *testing.T) {
+	m := null.MapOf[CustomID, float64]{12: 1.5, 345: 2}
+
+	v, err := m.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`{"12":1.5,"345":2}`), v)
+
+	var scanned null.MapOf[CustomID, float64]
+	assert.NoError(t, scanned.Scan(v))
+	assert.Equal(t, m, scanned)
+
+	assert.Error(t, scanned.Scan(`{"x":1}`))
+
+	var merged null.MapOf[CustomID, float64]
+	assert.NoError(t, null.ScanMapOfMerge(`{"12":1}`, &merged))
+	assert.NoError(t, null.UnmarshalMapOfMerge([]byte(`{"34":2}`), &merged))
+	assert.Equal(t, null.MapOf[CustomID, float64]{12: 1, 34: 2}, merged)
+
+	b, err := m.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"12":1.5,"345":2}`, string(b))
+
+	// a Map[V] can be converted to and from a MapOf[string, V]
+	assert.Equal(t, null.MapOf[string, int]{"a": 1}, null.MapOf[string, int](null.Map[int]{"a": 1}))
+}
+
+func TestMapMergePatch(t *testing.T) {
+	m := null.Map[string]{"a": "1", "b": "2"}
+
+	patched, err := m.MergePatch(null.JSON(`{"a": null, "b": "3", "c": "4"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, null.Map[string]{"b": "3", "c": "4"}, patched)
+	assert.Equal(t, null.Map[string]{"a": "1", "b": "2"}, m) // unchanged
+
+	// nested values are merged
+	settings := null.Map[map[string]any]{"x": {"theme": "dark", "size": 1.0}}
+	patchedSettings, err := settings.MergePatch(null.JSON(`{"x": {"size": null, "lang": "eng"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, null.Map[map[string]any]{"x": {"theme": "dark", "lang": "eng"}}, patchedSettings)
+
+	// a null patch clears the map
+	patched, err = m.MergePatch(null.JSON(`null`))
+	assert.NoError(t, err)
+	assert.Equal(t, null.Map[string]{}, patched)
+
+	var empty null.Map[string]
+	patched, err = empty.MergePatch(null.JSON(`{"a": "1"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, null.Map[string]{"a": "1"}, patched)
+
+	_, err = m.MergePatch(null.JSON(`{"a": 1}`))
+	assert.Error(t, err)
+
+	_, err = m.MergePatch(null.JSON(`"x"`))
+	assert.Error(t, err)
+
+	_, err = m.MergePatch(nil)
+	assert.EqualError(t, err, "unable to apply empty merge patch")
+
+	// also works with other key types
+	ids := null.MapOf[CustomID, int]{1: 10, 2: 20}
+	patchedIDs, err := ids.MergePatch(null.JSON(`{"1": null, "3": 30}`))
+	assert.NoError(t, err)
+	assert.Equal(t, null.MapOf[CustomID, int]{2: 20, 3: 30}, patchedIDs)
+}
+
+func TestMapUnmarshalAllocs(t *testing.T) {
+	data := []byte(`{"a":1,"b":2,"c":3}`)
+
+	// maps with string keys are decoded directly so cost no more than decoding a plain map
+	expected := testing.AllocsPerRun(100, func() {
+		var plain map[string]int
+		json.Unmarshal(data, &plain)
+	})
+
+	var m null.Map[int]
+	assert.Equal(t, expected, testing.AllocsPerRun(100, func() { null.UnmarshalMap(data, &m) }))
+
+	var l null.MapOf[Lang, int]
+	assert.Equal(t, expected, testing.AllocsPerRun(100, func() { null.UnmarshalMapOf(data, &l) }))
+	assert.Equal(t, null.MapOf[Lang, int]{"a": 1, "b": 2, "c": 3}, l)
+}
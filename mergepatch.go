@@ -0,0 +1,132 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var errEmptyMergePatch = errors.New("unable to apply empty merge patch")
+
+// CreateMergePatch returns the RFC 7386 merge patch which when applied to from gives to, e.g. for recording what changed
+// between two versions of a document. Note that merge patches can't distinguish between object members which are null
+// and those which are absent, so null members in to will be removed by the patch. Empty JSON is treated as null.
+func CreateMergePatch(from, to JSON) (JSON, error) {
+	fromValue, err := decodeMergePatchValue(from)
+	if err != nil {
+		return nil, err
+	}
+	toValue, err := decodeMergePatchValue(to)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalMergePatchValue(createMergePatch(fromValue, toValue))
+}
+
+// applies a merge patch to a target value as described in RFC 7386 section 2
+func applyMergePatch(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = make(map[string]any, len(patchObj))
+	}
+
+	for k, v := range patchObj {
+		if v == nil {
+			delete(targetObj, k)
+		} else {
+			targetObj[k] = applyMergePatch(targetObj[k], v)
+		}
+	}
+	return targetObj
+}
+
+// creates a merge patch which transforms from into to
+func createMergePatch(from, to any) any {
+	fromObj, fromIsObj := from.(map[string]any)
+	toObj, toIsObj := to.(map[string]any)
+
+	// a patch which isn't an object replaces the whole target, even if it is the same
+	if !fromIsObj || !toIsObj {
+		return removeNullMembers(to)
+	}
+
+	patch := make(map[string]any)
+
+	for k := range fromObj {
+		if v, exists := toObj[k]; !exists || v == nil {
+			patch[k] = nil
+		}
+	}
+
+	for k, toV := range toObj {
+		fromV, exists := fromObj[k]
+		if toV == nil {
+			continue
+		}
+
+		if !exists {
+			patch[k] = removeNullMembers(toV)
+			continue
+		}
+
+		_, fromVIsObj := fromV.(map[string]any)
+		_, toVIsObj := toV.(map[string]any)
+
+		if fromVIsObj && toVIsObj {
+			if sub := createMergePatch(fromV, toV).(map[string]any); len(sub) > 0 {
+				patch[k] = sub
+			}
+		} else if !jsonValuesEqual(fromV, toV) {
+			patch[k] = removeNullMembers(toV)
+		}
+	}
+
+	return patch
+}
+
+// removes null members from objects since they can't be represented in a merge patch
+func removeNullMembers(v any) any {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+
+	cleaned := make(map[string]any, len(obj))
+	for k, e := range obj {
+		if e != nil {
+			cleaned[k] = removeNullMembers(e)
+		}
+	}
+	return cleaned
+}
+
+// decodes JSON for a merge patch operation, treating empty JSON as null
+func decodeMergePatchValue(j JSON) (any, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+
+	if !json.Valid(j) {
+		return nil, fmt.Errorf("unable to merge patch invalid JSON")
+	}
+	return decodeJSONValue(j)
+}
+
+// marshals the result of a merge patch operation without HTML escaping so that strings it didn't touch aren't changed
+func marshalMergePatchValue(v any) (JSON, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
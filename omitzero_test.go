@@ -0,0 +1,103 @@
+//go:build go1.24
+
+package null_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type CustomZeroID int64
+
+func (i CustomZeroID) IsZero() bool                 { return null.IsZero(i) }
+func (i CustomZeroID) MarshalJSON() ([]byte, error) { return null.MarshalInt(i) }
+
+func TestOmitZero(t *testing.T) {
+	type payload struct {
+		Int      null.Int                   `json:"int,omitzero"`
+		Int64    null.Int64                 `json:"int64,omitzero"`
+		Uint     null.Uint                  `json:"uint,omitzero"`
+		Float    null.Float64               `json:"float,omitzero"`
+		Bool     null.Bool                  `json:"bool,omitzero"`
+		String   null.String                `json:"string,omitzero"`
+		Time     null.Time                  `json:"time,omitzero"`
+		Date     null.Date                  `json:"date,omitzero"`
+		Duration null.Duration              `json:"duration,omitzero"`
+		UUID     null.UUID                  `json:"uuid,omitzero"`
+		Map      null.Map[string]           `json:"map,omitzero"`
+		Slice    null.Slice[int]            `json:"slice,omitzero"`
+		JSON     null.JSON                  `json:"json,omitzero"`
+		Value    null.Value[ContactID]      `json:"value,omitzero"`
+		Optional null.Optional[null.String] `json:"optional,omitzero"`
+		Custom   CustomZeroID               `json:"custom,omitzero"`
+	}
+
+	b, err := json.Marshal(payload{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{}`, string(b))
+
+	// empty but non-nil maps and slices, and JSON null are also omitted
+	b, err = json.Marshal(payload{Map: null.Map[string]{}, Slice: null.Slice[int]{}, JSON: null.NullJSON})
+	assert.NoError(t, err)
+	assert.Equal(t, `{}`, string(b))
+
+	b, err = json.Marshal(payload{
+		Int:      1,
+		Int64:    2,
+		Uint:     3,
+		Float:    1.5,
+		Bool:     true,
+		String:   "foo",
+		Time:     null.Time{Time: time.Date(2026, 10, 17, 13, 30, 0, 0, time.UTC)},
+		Date:     null.Date{Year: 2026, Month: 10, Day: 17},
+		Duration: null.Duration(time.Hour),
+		UUID:     null.UUID{1},
+		Map:      null.Map[string]{"foo": "bar"},
+		Slice:    null.Slice[int]{1},
+		JSON:     null.JSON(`[]`),
+		Value:    null.Value[ContactID]{V: 4},
+		Optional: null.Optional[null.String]{Set: true, Null: true},
+		Custom:   5,
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"int": 1,
+		"int64": 2,
+		"uint": 3,
+		"float": 1.5,
+		"bool": true,
+		"string": "foo",
+		"time": "2026-10-17T13:30:00Z",
+		"date": "2026-10-17",
+		"duration": "PT1H",
+		"uuid": "01000000-0000-0000-0000-000000000000",
+		"map": {"foo": "bar"},
+		"slice": [1],
+		"json": [],
+		"value": 4,
+		"optional": null,
+		"custom": 5
+	}`, string(b))
+}
+
+func TestOptionalOmitZero(t *testing.T) {
+	type update struct {
+		Name null.Optional[null.String] `json:"name,omitzero"`
+		Age  null.Optional[null.Int64]  `json:"age"`
+	}
+
+	// an unset optional is only omitted with omitzero, otherwise it's written as null and unmarshals as set
+	b, err := json.Marshal(update{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"age":null}`, string(b))
+
+	var u update
+	assert.NoError(t, json.Unmarshal(b, &u))
+	assert.False(t, u.Name.IsSet())
+	assert.True(t, u.Age.IsSet())
+	assert.True(t, u.Age.IsNull())
+}
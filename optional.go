@@ -0,0 +1,136 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Optional is a value which tracks whether it was provided at all and whether it was provided as null. This is useful
+// for PATCH style APIs where a field being absent means leave it unchanged and a field being null means clear it, e.g.
+//
+//	type ContactUpdate struct {
+//	    Name null.Optional[null.String] `json:"name"`
+//	}
+//
+// Unlike the other types in this package, the zero value of the wrapped type is not treated as null.
+type Optional[T any] struct {
+	V    T    // the value if it was set and not null
+	Set  bool // whether a value was provided, which can be null
+	Null bool // whether the value provided was null
+}
+
+// IsSet returns whether a value was provided, which can be null.
+func (o Optional[T]) IsSet() bool { return o.Set }
+
+// IsNull returns whether the value was provided as null.
+func (o Optional[T]) IsNull() bool { return o.Set && o.Null }
+
+// IsZero returns whether a value wasn't provided, which allows it to be omitted with the omitzero JSON tag (Go 1.24+)
+// whilst a value provided as null is still written as null
+func (o Optional[T]) IsZero() bool { return !o.Set }
+
+// Apply sets dst to the value of this optional if it was provided, using the zero value if it was provided as null,
+// and leaves dst unchanged otherwise, e.g. applying an Optional[null.String] to a null.String field.
+func (o Optional[T]) Apply(dst *T) {
+	if o.Set {
+		if o.Null {
+			var zero T
+			*dst = zero
+		} else {
+			*dst = o.V
+		}
+	}
+}
+
+// Scan implements the Scanner interface, marking the value as set
+func (o *Optional[T]) Scan(value any) error {
+	// let the wrapped type decide what NULL scans as, e.g. an empty map for null.Map
+	var v T
+	if err := scanInto(value, &v); err != nil {
+		return err
+	}
+
+	*o = Optional[T]{V: v, Set: true, Null: value == nil}
+	return nil
+}
+
+// Value implements the Valuer interface, writing NULL if the value is null or not set
+func (o Optional[T]) Value() (driver.Value, error) {
+	if !o.Set || o.Null {
+		return nil, nil
+	}
+	if valuer, ok := any(o.V).(driver.Valuer); ok {
+		return valuer.Value()
+	}
+
+	rv := reflect.ValueOf(o.V)
+
+	// the default converter doesn't support large uint64 values so write those as strings like UintValue does
+	if rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uintptr && rv.Uint() > math.MaxInt64 {
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	}
+
+	// nor arrays, which are written like Value does
+	if rv.Kind() == reflect.Array {
+		return arrayValue(rv)
+	}
+
+	return driver.DefaultParameterConverter.ConvertValue(o.V)
+}
+
+// UnmarshalJSON implements the Unmarshaller interface, marking the value as set even if it is null
+func (o *Optional[T]) UnmarshalJSON(b []byte) error {
+	var v T
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*o = Optional[T]{V: v, Set: true, Null: bytes.Equal(bytes.TrimSpace(b), []byte(`null`))}
+	return nil
+}
+
+// MarshalJSON implements the Marshaller interface, writing null if the value is null or not set. A field which wasn't
+// set is only omitted if it has the omitzero tag (Go 1.24+), otherwise it's written as null and unmarshals as set.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Set || o.Null {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(o.V)
+}
+
+// UnmarshalText implements the TextUnmarshaler interface, marking the value as set and as null if the text is empty
+func (o *Optional[T]) UnmarshalText(b []byte) error {
+	var v T
+	if err := unmarshalTextInto(b, &v); err != nil {
+		return err
+	}
+
+	*o = Optional[T]{V: v, Set: true, Null: len(b) == 0}
+	return nil
+}
+
+// MarshalText implements the TextMarshaler interface, writing empty text if the value is null or not set
+func (o Optional[T]) MarshalText() ([]byte, error) {
+	if !o.Set || o.Null {
+		return []byte{}, nil
+	}
+	return marshalTextOf(o.V)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (o *Optional[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(o, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (o Optional[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(o, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (o Optional[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(o, name) }
@@ -0,0 +1,162 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptional(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value VARCHAR(255) NULL);`)
+
+	tcs := []struct {
+		value     null.Optional[string]
+		dbValue   driver.Value
+		marshaled []byte
+		scanned   null.Optional[string]
+	}{
+		{null.Optional[string]{V: "foo", Set: true}, "foo", []byte(`"foo"`), null.Optional[string]{V: "foo", Set: true}},
+		{null.Optional[string]{V: "", Set: true}, "", []byte(`""`), null.Optional[string]{V: "", Set: true}},
+		{null.Optional[string]{Set: true, Null: true}, nil, []byte(`null`), null.Optional[string]{Set: true, Null: true}},
+		{null.Optional[string]{}, nil, []byte(`null`), null.Optional[string]{Set: true, Null: true}},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		var scanned null.Optional[string]
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tc.scanned, scanned, "scanned value mismatch for %v", tc.value)
+
+		marshaled, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+		var unmarshaled null.Optional[string]
+		err = json.Unmarshal(marshaled, &unmarshaled)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.scanned, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+func TestOptionalPatch(t *testing.T) {
+	type contact struct {
+		Name null.String
+		Age  null.Int64
+	}
+
+	type contactUpdate struct {
+		Name null.Optional[null.String] `json:"name"`
+		Age  null.Optional[null.Int64]  `json:"age"`
+	}
+
+	tcs := []struct {
+		patch    string
+		expected contact
+	}{
+		{`{}`, contact{Name: "Bob", Age: 42}},
+		{`{"name": "Jim"}`, contact{Name: "Jim", Age: 42}},
+		{`{"name": null}`, contact{Name: null.NullString, Age: 42}},
+		{`{"name": ""}`, contact{Name: null.NullString, Age: 42}},
+		{`{"age": 43}`, contact{Name: "Bob", Age: 43}},
+		{`{"name": "Jim", "age": null}`, contact{Name: "Jim", Age: null.NullInt64}},
+	}
+
+	for _, tc := range tcs {
+		c := contact{Name: "Bob", Age: 42}
+
+		var update contactUpdate
+		err := json.Unmarshal([]byte(tc.patch), &update)
+		assert.NoError(t, err)
+
+		update.Name.Apply(&c.Name)
+		update.Age.Apply(&c.Age)
+
+		assert.Equal(t, tc.expected, c, "patched value mismatch for %s", tc.patch)
+	}
+
+	var update contactUpdate
+	assert.NoError(t, json.Unmarshal([]byte(`{"name": null}`), &update))
+	assert.True(t, update.Name.IsSet())
+	assert.True(t, update.Name.IsNull())
+	assert.False(t, update.Age.IsSet())
+	assert.False(t, update.Age.IsNull())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"age": "foo"}`), &update))
+}
+
+func TestOptionalApply(t *testing.T) {
+	// null uses the zero value even if V was given a value
+	s := "foo"
+	null.Optional[string]{V: "bar", Set: true, Null: true}.Apply(&s)
+	assert.Equal(t, "", s)
+
+	s = "foo"
+	null.Optional[string]{V: "bar"}.Apply(&s)
+	assert.Equal(t, "foo", s)
+
+	s = "foo"
+	null.Optional[string]{V: "bar", Set: true}.Apply(&s)
+	assert.Equal(t, "bar", s)
+
+	// e.g. a scanned NULL map is empty but applies as nil
+	var o null.Optional[null.Map[string]]
+	assert.NoError(t, o.Scan(nil))
+	m := null.Map[string]{"foo": "bar"}
+	o.Apply(&m)
+	assert.Nil(t, m)
+}
+
+func TestOptionalScan(t *testing.T) {
+	// wrapped types decide what NULL scans as
+	var m null.Optional[null.Map[string]]
+	assert.NoError(t, m.Scan(nil))
+	assert.Equal(t, null.Optional[null.Map[string]]{V: null.Map[string]{}, Set: true, Null: true}, m)
+
+	assert.NoError(t, m.Scan(`{"foo": "bar"}`))
+	assert.Equal(t, null.Optional[null.Map[string]]{V: null.Map[string]{"foo": "bar"}, Set: true}, m)
+
+	var c null.Optional[Count]
+	assert.NoError(t, c.Scan(nil))
+	assert.Equal(t, null.Optional[Count]{V: NullCount, Set: true, Null: true}, c)
+
+	var i null.Optional[int]
+	assert.NoError(t, i.Scan(int64(0)))
+	assert.Equal(t, null.Optional[int]{V: 0, Set: true}, i)
+	v, err := i.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), v)
+
+	var u null.Optional[uint64]
+	assert.NoError(t, u.Scan("18446744073709551615"))
+	v, err = u.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "18446744073709551615", v)
+
+	var h null.Optional[hash]
+	assert.NoError(t, h.Scan([]byte{1, 2, 3, 4}))
+	v, err = h.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3, 4}, v)
+
+	assert.Error(t, i.Scan("foo"))
+}
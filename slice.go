@@ -0,0 +1,135 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+)
+
+// Slice is a generic slice which is written to the database as a JSON array.
+type Slice[T any] []T
+
+// IsZero returns whether this is the zero value
+func (s Slice[T]) IsZero() bool { return len(s) == 0 }
+
+// Scan implements the Scanner interface
+func (s *Slice[T]) Scan(value any) error { return ScanSlice(value, s) }
+
+// Value implements the Valuer interface
+func (s Slice[T]) Value() (driver.Value, error) { return SliceValue(s) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (s *Slice[T]) UnmarshalJSON(data []byte) error { return UnmarshalSlice(data, s) }
+
+// MarshalJSON implements the Marshaller interface
+func (s Slice[T]) MarshalJSON() ([]byte, error) { return MarshalSlice(s) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (s *Slice[T]) UnmarshalText(b []byte) error { return UnmarshalSliceText(b, s) }
+
+// MarshalText implements the TextMarshaler interface
+func (s Slice[T]) MarshalText() ([]byte, error) { return MarshalSliceText(s) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (s *Slice[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(s, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (s Slice[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(s, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (s Slice[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(s, name) }
+
+// ScanSlice scans a nullable text or JSON into a slice, using an empty slice for NULL.
+func ScanSlice[T any](value any, s *Slice[T]) error {
+	if value == nil {
+		*s = make(Slice[T], 0)
+		return nil
+	}
+
+	var raw []byte
+	switch typed := value.(type) {
+	case string:
+		raw = []byte(typed)
+	case []byte:
+		raw = typed
+	default:
+		return fmt.Errorf("unable to scan %T as slice", value)
+	}
+
+	// empty bytes is same as nil
+	if len(raw) == 0 {
+		*s = make(Slice[T], 0)
+		return nil
+	}
+
+	scanned, err := unmarshalSlice[T](raw)
+	if err != nil {
+		return err
+	}
+
+	*s = scanned
+	return nil
+}
+
+// SliceValue converts a slice to NULL if it is empty.
+func SliceValue[T any](s Slice[T]) (driver.Value, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	return json.Marshal([]T(s))
+}
+
+// MarshalSlice marshals a slice, returning null for an empty slice.
+func MarshalSlice[T any](s Slice[T]) ([]byte, error) {
+	if len(s) == 0 {
+		return json.Marshal(nil)
+	}
+	return json.Marshal([]T(s))
+}
+
+// UnmarshalSlice unmarshals a new slice from JSON, using an empty slice for null.
+func UnmarshalSlice[T any](data []byte, s *Slice[T]) error {
+	unmarshaled, err := unmarshalSlice[T](data)
+	if err != nil {
+		return err
+	}
+
+	*s = unmarshaled
+	return nil
+}
+
+// UnmarshalSliceText unmarshals a slice from JSON text, using an empty slice for empty text.
+func UnmarshalSliceText[T any](data []byte, s *Slice[T]) error {
+	if len(data) == 0 {
+		*s = make(Slice[T], 0)
+		return nil
+	}
+	return UnmarshalSlice(data, s)
+}
+
+// MarshalSliceText marshals a slice to JSON text, using empty text for an empty slice.
+func MarshalSliceText[T any](s Slice[T]) ([]byte, error) {
+	if len(s) == 0 {
+		return []byte{}, nil
+	}
+	return MarshalSlice(s)
+}
+
+// unmarshals JSON into a new slice rather than reusing the backing array and elements of an existing one, using an
+// empty slice for null
+func unmarshalSlice[T any](data []byte) (Slice[T], error) {
+	var u []T
+	if err := json.Unmarshal(data, &u); err != nil {
+		return nil, err
+	}
+
+	if u == nil {
+		u = make([]T, 0) // initialize empty slice
+	}
+	return u, nil
+}
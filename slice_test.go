@@ -0,0 +1,121 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlice(t *testing.T) {
+	db := getTestDB()
+
+	testSlice := func() {
+		tcs := []struct {
+			value     null.Slice[string]
+			dbValue   driver.Value
+			marshaled []byte
+		}{
+			{null.Slice[string]{"foo", "bar"}, []byte(`["foo","bar"]`), []byte(`["foo","bar"]`)},
+			{null.Slice[string]{}, nil, []byte(`null`)},
+			{null.Slice[string](nil), nil, []byte(`null`)},
+		}
+
+		for _, tc := range tcs {
+			mustExec(db, `DELETE FROM test`)
+
+			dbValue, err := tc.value.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+			// check writing the value to the database
+			_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+			assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+			rows, err := db.Query(`SELECT value FROM test;`)
+			assert.NoError(t, err)
+
+			scanned := null.Slice[string]{}
+			assert.True(t, rows.Next())
+			err = rows.Scan(&scanned)
+			assert.NoError(t, err)
+
+			// we never return a nil slice even if that's what we wrote
+			expected := tc.value
+			if expected == nil {
+				expected = null.Slice[string]{}
+			}
+
+			assert.Equal(t, expected, scanned, "scanned value mismatch for %v", tc.value)
+
+			marshaled, err := json.Marshal(tc.value)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+			unmarshaled := null.Slice[string]{}
+			err = json.Unmarshal(marshaled, &unmarshaled)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+		}
+	}
+
+	// test with TEXT column
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value text null);`)
+	testSlice()
+
+	// test with JSONB column
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value jsonb null);`)
+	testSlice()
+}
+
+func TestScanSlice(t *testing.T) {
+	s := null.Slice[int]{1, 2, 3}
+	prev := s
+
+	assert.NoError(t, s.Scan([]byte(`[4, 5]`)))
+	assert.Equal(t, null.Slice[int]{4, 5}, s)
+	assert.Equal(t, null.Slice[int]{1, 2, 3}, prev) // previous value not modified
+
+	assert.NoError(t, s.Scan(nil))
+	assert.Equal(t, null.Slice[int]{}, s)
+
+	assert.NoError(t, s.Scan(`null`))
+	assert.Equal(t, null.Slice[int]{}, s)
+
+	assert.EqualError(t, s.Scan(123), "unable to scan int as slice")
+	assert.Error(t, s.Scan(`{"foo": 1}`))
+
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &s))
+	assert.Equal(t, null.Slice[int]{}, s)
+}
+
+type point struct {
+	A int
+	B int
+}
+
+func TestSliceUnmarshalFresh(t *testing.T) {
+	s := null.Slice[point]{{A: 1, B: 2}}
+	prev := s
+
+	// unmarshaling replaces the slice without reusing its elements or modifying the previous one
+	assert.NoError(t, json.Unmarshal([]byte(`[{"A": 9}]`), &s))
+	assert.Equal(t, null.Slice[point]{{A: 9}}, s)
+	assert.Equal(t, null.Slice[point]{{A: 1, B: 2}}, prev)
+
+	assert.NoError(t, s.UnmarshalText([]byte(`[{"B": 8}]`)))
+	assert.Equal(t, null.Slice[point]{{B: 8}}, s)
+
+	assert.NoError(t, s.Scan([]byte(`[{"A": 7}]`)))
+	assert.Equal(t, null.Slice[point]{{A: 7}}, s)
+
+	// errors leave the slice unchanged
+	assert.Error(t, json.Unmarshal([]byte(`[{"A": "x"}]`), &s))
+	assert.Equal(t, null.Slice[point]{{A: 7}}, s)
+
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &s))
+	assert.Equal(t, null.Slice[point]{}, s)
+}
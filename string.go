@@ -4,6 +4,9 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"unicode/utf8"
 )
 
 // String is string that will write as null when it is empty, both to databases and JSON
@@ -13,6 +16,9 @@ type String string
 // NullString is our constant for an String value that will be written as null
 const NullString = String("")
 
+// IsZero returns whether this is the zero value
+func (s String) IsZero() bool { return s == NullString }
+
 // Scan implements the Scanner interface
 func (s *String) Scan(value any) error { return ScanString(value, s) }
 
@@ -25,8 +31,40 @@ func (s *String) UnmarshalJSON(b []byte) error { return UnmarshalString(b, s) }
 // MarshalJSON implements the Marshaller interface
 func (s String) MarshalJSON() ([]byte, error) { return MarshalString(s) }
 
+// UnmarshalText implements the TextUnmarshaler interface
+func (s *String) UnmarshalText(b []byte) error { return UnmarshalStringText(b, s) }
+
+// MarshalText implements the TextMarshaler interface
+func (s String) MarshalText() ([]byte, error) { return MarshalStringText(s) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (s *String) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(s, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (s String) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(s, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (s String) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(s, name) }
+
 // ScanString scans a nullable CHAR/TEXT into a string type, using empty string for NULL.
-func ScanString[T ~string](value any, s *T) error {
+func ScanString[T ~string](value any, s *T) error { return ScanStringSentinel(value, s, "") }
+
+// StringValue converts a string type value to NULL if it is empty
+func StringValue[T ~string](s T) (driver.Value, error) { return StringValueSentinel(s, "") }
+
+// UnmarshalString unmarshals a string type from JSON, using empty string for null.
+func UnmarshalString[T ~string](b []byte, s *T) error { return UnmarshalStringSentinel(b, s, "") }
+
+// MarshalString marshals a string type to JSON, using null for empty strings.
+func MarshalString[T ~string](s T) ([]byte, error) { return MarshalStringSentinel(s, "") }
+
+// ScanStringSentinel scans a nullable CHAR/TEXT into a string type, using the given sentinel value for NULL. This is
+// for types where the empty string is a meaningful value and something else like "unknown" means no value.
+func ScanStringSentinel[T ~string](value any, s *T, sentinel T) error {
 	ns := sql.NullString{}
 
 	if err := ns.Scan(value); err != nil {
@@ -34,7 +72,7 @@ func ScanString[T ~string](value any, s *T) error {
 	}
 
 	if !ns.Valid {
-		*s = ""
+		*s = sentinel
 		return nil
 	}
 
@@ -42,16 +80,27 @@ func ScanString[T ~string](value any, s *T) error {
 	return nil
 }
 
-// StringValue converts a string type value to NULL if it is empty
-func StringValue[T ~string](s T) (driver.Value, error) {
-	if s == "" {
+// StringValueSentinel converts a string type value to NULL if it is the given sentinel value.
+func StringValueSentinel[T ~string](s T, sentinel T) (driver.Value, error) {
+	if s == sentinel {
 		return nil, nil
 	}
 	return string(s), nil
 }
 
-// UnmarshalString unmarshals a string type from JSON, using empty string for null.
-func UnmarshalString[T ~string](b []byte, s *T) error {
+// UnmarshalStringSentinel unmarshals a string type from JSON, using the given sentinel value for null.
+func UnmarshalStringSentinel[T ~string](b []byte, s *T, sentinel T) error {
+	if string(b) == "null" {
+		*s = sentinel
+		return nil
+	}
+
+	// fast path for strings which don't need unescaping, anything else goes through encoding/json
+	if raw, ok := unquoteSimpleJSON(b); ok {
+		*s = T(raw)
+		return nil
+	}
+
 	var val *string
 
 	if err := json.Unmarshal(b, &val); err != nil {
@@ -59,7 +108,7 @@ func UnmarshalString[T ~string](b []byte, s *T) error {
 	}
 
 	if val == nil {
-		*s = ""
+		*s = sentinel
 		return nil
 	}
 
@@ -67,10 +116,86 @@ func UnmarshalString[T ~string](b []byte, s *T) error {
 	return nil
 }
 
-// MarshalJSON marshals a string type to JSON, using null for empty strings.
-func MarshalString[T ~string](s T) ([]byte, error) {
-	if s == "" {
-		return json.Marshal(nil)
+// MarshalStringSentinel marshals a string type to JSON, using null for the given sentinel value.
+func MarshalStringSentinel[T ~string](s T, sentinel T) ([]byte, error) {
+	if s == sentinel {
+		return []byte("null"), nil
+	}
+
+	// how invalid UTF-8 is replaced and how \b and \f are escaped (short escapes since Go 1.22, \u0008 and \u000c
+	// before) varies between versions of encoding/json so leave those rare cases to it
+	if !utf8.ValidString(string(s)) || strings.ContainsAny(string(s), "\b\f") {
+		return json.Marshal(string(s))
+	}
+
+	return appendJSONString(make([]byte, 0, len(s)+2), string(s)), nil
+}
+
+// returns the contents of b if it's a quoted JSON string which contains no escapes, control characters or invalid UTF-8
+func unquoteSimpleJSON(b []byte) ([]byte, bool) {
+	if len(b) < 2 || b[0] != '"' || b[len(b)-1] != '"' {
+		return nil, false
 	}
-	return json.Marshal(string(s))
+	raw := b[1 : len(b)-1]
+	for _, c := range raw {
+		if c < 0x20 || c == '"' || c == '\\' {
+			return nil, false
+		}
+	}
+	return raw, utf8.Valid(raw)
+}
+
+// appends the valid UTF-8 string s, which must not contain \b or \f, to dst as a quoted JSON string, escaped as
+// encoding/json does it in all supported Go versions, i.e. including escaping of HTML characters and of U+2028 and
+// U+2029
+func appendJSONString(dst []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				dst = append(dst, '\\', c)
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hex[r&0xF])
+			start = i + size
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
+
+// UnmarshalStringText unmarshals a string type from text.
+func UnmarshalStringText[T ~string](b []byte, s *T) error {
+	*s = T(b)
+	return nil
+}
+
+// MarshalStringText marshals a string type to text.
+func MarshalStringText[T ~string](s T) ([]byte, error) {
+	return []byte(s), nil
 }
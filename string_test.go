@@ -109,3 +109,144 @@ func TestCustomString(t *testing.T) {
 		assert.Equal(t, tc.value, unmarshaled, "unmarshaled mismatch for %v", tc.value)
 	}
 }
+
+type Status string
+
+const NullStatus = Status("unknown")
+
+func (s *Status) Scan(value any) error         { return null.ScanStringSentinel(value, s, NullStatus) }
+func (s Status) Value() (driver.Value, error)  { return null.StringValueSentinel(s, NullStatus) }
+func (s Status) MarshalJSON() ([]byte, error)  { return null.MarshalStringSentinel(s, NullStatus) }
+func (s *Status) UnmarshalJSON(b []byte) error { return null.UnmarshalStringSentinel(b, s, NullStatus) }
+
+func TestStringSentinel(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value VARCHAR(255) NULL);`)
+
+	tcs := []struct {
+		value     Status
+		dbValue   driver.Value
+		marshaled []byte
+	}{
+		{Status("active"), "active", []byte(`"active"`)},
+		{Status(""), "", []byte(`""`)},
+		{NullStatus, nil, []byte(`null`)},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		var scanned Status
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tc.value, scanned, "scanned value mismatch for %v", tc.value)
+
+		marshaled, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+		var unmarshaled Status
+		err = json.Unmarshal(marshaled, &unmarshaled)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+func TestStringJSONCompat(t *testing.T) {
+	strs := []string{"hello", "x", `"quoted"`, `back\slash`, "<b>&amp;</b>", "tab\tnew\nline\r", "\b\f\x00\x01\x1f\x7f",
+		"héllo wörld", "日本語", "emoji 😀", "line\u2028para\u2029", "bad \xff utf8", "\xe6\x97", "trailing \xe2\x80"}
+	for c := 1; c < 128; c++ {
+		strs = append(strs, string(rune(c)))
+	}
+
+	for _, s := range strs {
+		// marshaling should be byte for byte the same as encoding/json
+		expected, _ := json.Marshal(s)
+		actual, err := null.MarshalString(null.String(s))
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(actual), "marshal mismatch for %q", s)
+
+		// and unmarshaling should give the same result
+		var expectedStr string
+		assert.NoError(t, json.Unmarshal(expected, &expectedStr))
+
+		var actualStr null.String
+		assert.NoError(t, null.UnmarshalString(expected, &actualStr))
+		assert.Equal(t, null.String(expectedStr), actualStr, "unmarshal mismatch for %s", expected)
+	}
+
+	// unescaped input which encoding/json wouldn't produce but will accept
+	for _, b := range []string{`"<>&"`, "\"\u2028\"", "\"bad \xff\"", `"\u00e9\/"`, ` "padded" `} {
+		var expected string
+		assert.NoError(t, json.Unmarshal([]byte(b), &expected))
+
+		var actual null.String
+		assert.NoError(t, null.UnmarshalString([]byte(b), &actual))
+		assert.Equal(t, null.String(expected), actual, "unmarshal mismatch for %s", b)
+	}
+
+	// as should errors
+	for _, b := range []string{``, `"`, `"unterminated`, "\"ctrl\x01\"", `123`, `"a" "b"`} {
+		var expected *string
+		expectedErr := json.Unmarshal([]byte(b), &expected)
+
+		var actual null.String
+		assert.EqualError(t, null.UnmarshalString([]byte(b), &actual), expectedErr.Error(), "error mismatch for %s", b)
+	}
+}
+
+func TestStringJSONAllocs(t *testing.T) {
+	var s null.String
+	b := []byte(`"hello world"`)
+
+	assert.Equal(t, 1.0, testing.AllocsPerRun(100, func() { null.MarshalString(null.String("hello world")) }))
+	assert.Equal(t, 1.0, testing.AllocsPerRun(100, func() { null.UnmarshalString(b, &s) }))
+}
+
+func BenchmarkMarshalString(b *testing.B) {
+	b.Run("null", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			null.MarshalString(null.String("hello world"))
+		}
+	})
+	b.Run("encoding/json", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			json.Marshal("hello world")
+		}
+	})
+}
+
+func BenchmarkUnmarshalString(b *testing.B) {
+	data := []byte(`"hello world"`)
+
+	b.Run("null", func(b *testing.B) {
+		b.ReportAllocs()
+		var s null.String
+		for n := 0; n < b.N; n++ {
+			null.UnmarshalString(data, &s)
+		}
+	})
+	b.Run("encoding/json", func(b *testing.B) {
+		b.ReportAllocs()
+		var s *string
+		for n := 0; n < b.N; n++ {
+			json.Unmarshal(data, &s)
+		}
+	})
+}
@@ -0,0 +1,228 @@
+package null_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"flag"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestText(t *testing.T) {
+	t1 := time.Date(2026, 10, 17, 13, 30, 15, 0, time.UTC)
+	u1 := null.UUID{0x9b, 0x7b, 0x6b, 0x8a, 0x8e, 0x3c, 0x4f, 0x9a, 0x9a, 0x43, 0x1d, 0x8b, 0x5a, 0x1f, 0x2c, 0x3d}
+
+	tcs := []struct {
+		value encoding.TextMarshaler
+		text  string
+		empty encoding.TextUnmarshaler
+	}{
+		{null.Int(123), "123", new(null.Int)},
+		{null.NullInt, "", new(null.Int)},
+		{null.Int64(-123), "-123", new(null.Int64)},
+		{null.NullInt64, "", new(null.Int64)},
+		{null.Uint(123), "123", new(null.Uint)},
+		{null.Uint64(math.MaxUint64), "18446744073709551615", new(null.Uint64)},
+		{null.NullUint64, "", new(null.Uint64)},
+		{null.Float64(1.5), "1.5", new(null.Float64)},
+		{null.Float32(0.1), "0.1", new(null.Float32)},
+		{null.NullFloat64, "", new(null.Float64)},
+		{null.Bool(true), "true", new(null.Bool)},
+		{null.NullBool, "", new(null.Bool)},
+		{null.String("foo"), "foo", new(null.String)},
+		{null.NullString, "", new(null.String)},
+		{null.Time{Time: t1}, "2026-10-17T13:30:15Z", new(null.Time)},
+		{null.NullTime, "", new(null.Time)},
+		{null.TimeUTC{Time: t1}, "2026-10-17T13:30:15Z", new(null.TimeUTC)},
+		{null.NullTimeUTC, "", new(null.TimeUTC)},
+		{null.Date{Year: 2026, Month: 10, Day: 17}, "2026-10-17", new(null.Date)},
+		{null.NullDate, "", new(null.Date)},
+		{null.Duration(90 * time.Minute), "PT1H30M", new(null.Duration)},
+		{null.NullDuration, "", new(null.Duration)},
+		{u1, "9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d", new(null.UUID)},
+		{null.NullUUID, "", new(null.UUID)},
+		{null.JSON(`{"foo":1}`), `{"foo":1}`, new(null.JSON)},
+		{null.NullJSON, "", new(null.JSON)},
+		{null.CanonicalJSON(`{"foo":1}`), `{"foo":1}`, new(null.CanonicalJSON)},
+		{null.Map[int]{"foo": 1}, `{"foo":1}`, &null.Map[int]{}},
+		{null.Map[int]{}, "", &null.Map[int]{}},
+		{null.Slice[int]{1, 2}, `[1,2]`, &null.Slice[int]{}},
+		{null.Slice[int]{}, "", &null.Slice[int]{}},
+		{null.Value[ContactID]{V: 123}, "123", &null.Value[ContactID]{}},
+		{null.Value[ContactID]{}, "", &null.Value[ContactID]{}},
+		{null.Value[float32]{V: 0.1}, "0.1", &null.Value[float32]{}},
+		{null.Value[null.UUID]{V: u1}, "9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d", &null.Value[null.UUID]{}},
+		{null.Optional[int]{V: 0, Set: true}, "0", &null.Optional[int]{}},
+		{null.Optional[string]{V: "foo", Set: true}, "foo", &null.Optional[string]{}},
+	}
+
+	for _, tc := range tcs {
+		text, err := tc.value.MarshalText()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.text, string(text), "text mismatch for %v", tc.value)
+
+		err = tc.empty.UnmarshalText(text)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, deref(tc.empty), "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+func deref(v any) any {
+	switch typed := v.(type) {
+	case *null.Int:
+		return *typed
+	case *null.Int64:
+		return *typed
+	case *null.Uint:
+		return *typed
+	case *null.Uint64:
+		return *typed
+	case *null.Float64:
+		return *typed
+	case *null.Float32:
+		return *typed
+	case *null.Bool:
+		return *typed
+	case *null.String:
+		return *typed
+	case *null.Time:
+		return *typed
+	case *null.TimeUTC:
+		return *typed
+	case *null.Date:
+		return *typed
+	case *null.Duration:
+		return *typed
+	case *null.UUID:
+		return *typed
+	case *null.JSON:
+		return *typed
+	case *null.CanonicalJSON:
+		return *typed
+	case *null.Map[int]:
+		return *typed
+	case *null.Slice[int]:
+		return *typed
+	case *null.Value[ContactID]:
+		return *typed
+	case *null.Value[float32]:
+		return *typed
+	case *null.Value[null.UUID]:
+		return *typed
+	case *null.Optional[int]:
+		return *typed
+	case *null.Optional[string]:
+		return *typed
+	}
+	panic("unexpected type")
+}
+
+func TestTextErrors(t *testing.T) {
+	var i null.Int
+	assert.Error(t, i.UnmarshalText([]byte("foo")))
+
+	var i8 CustomID8
+	assert.EqualError(t, null.UnmarshalIntText([]byte("300"), &i8), "value 300 is out of range for null_test.CustomID8")
+
+	var u null.Uint
+	assert.Error(t, u.UnmarshalText([]byte("-1")))
+
+	var b null.Bool
+	assert.Error(t, b.UnmarshalText([]byte("maybe")))
+
+	var f null.Float64
+	assert.Error(t, f.UnmarshalText([]byte("foo")))
+
+	var tm null.Time
+	assert.Error(t, tm.UnmarshalText([]byte("yesterday")))
+
+	var d null.Date
+	assert.Error(t, d.UnmarshalText([]byte("2026-13-01")))
+
+	var dur null.Duration
+	assert.Error(t, dur.UnmarshalText([]byte("1 hour")))
+
+	var uu null.UUID
+	assert.Error(t, uu.UnmarshalText([]byte("foo")))
+
+	var j null.JSON
+	assert.Error(t, j.UnmarshalText([]byte("{")))
+
+	// empty optional text means null
+	var o null.Optional[string]
+	assert.NoError(t, o.UnmarshalText([]byte("")))
+	assert.Equal(t, null.Optional[string]{Set: true, Null: true}, o)
+
+	// non-finite floats can be represented as text
+	text, err := null.MarshalFloatText(math.Inf(-1))
+	assert.NoError(t, err)
+	assert.Equal(t, "-Inf", string(text))
+
+	// custom duration types can use Go format
+	text, err = null.MarshalDurationText(CustomTimeout(90*time.Minute), null.DurationGo)
+	assert.NoError(t, err)
+	assert.Equal(t, "1h30m0s", string(text))
+
+	var c CustomTimeout
+	assert.NoError(t, null.UnmarshalDurationText(text, &c))
+	assert.Equal(t, CustomTimeout(90*time.Minute), c)
+
+	_, err = null.Value[coords]{V: coords{1, 2}}.MarshalText()
+	assert.EqualError(t, err, "unable to marshal null_test.coords as text")
+}
+
+func TestTextMapKeys(t *testing.T) {
+	m := map[null.Int64]string{1: "one", 2: "two"}
+
+	b, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"1":"one","2":"two"}`, string(b))
+
+	// unmarshaling plain maps like this isn't supported by encoding/json v1, but MapOf decodes keys itself so works with
+	// all versions
+	var unmarshaled null.MapOf[null.Int64, string]
+	assert.NoError(t, json.Unmarshal(b, &unmarshaled))
+	assert.Equal(t, null.MapOf[null.Int64, string](m), unmarshaled)
+
+	assert.EqualError(t, json.Unmarshal([]byte(`{"x":"one"}`), &unmarshaled), `unable to unmarshal "x" as int: strconv.ParseInt: parsing "x": invalid syntax`)
+
+	u := map[null.UUID]int{{1}: 1}
+	b, err = json.Marshal(u)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"01000000-0000-0000-0000-000000000000":1}`, string(b))
+
+	var unmarshaledUUIDs map[null.UUID]int
+	assert.NoError(t, json.Unmarshal(b, &unmarshaledUUIDs))
+	assert.Equal(t, u, unmarshaledUUIDs)
+
+	// plain maps keyed by types which are written to JSON as strings work with all versions
+	d := map[null.Date]null.String{{Year: 2026, Month: 10, Day: 17}: "today"}
+	b, err = json.Marshal(d)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"2026-10-17":"today"}`, string(b))
+
+	var unmarshaledDates map[null.Date]null.String
+	assert.NoError(t, json.Unmarshal(b, &unmarshaledDates))
+	assert.Equal(t, d, unmarshaledDates)
+}
+
+func TestTextFlags(t *testing.T) {
+	var limit null.Int
+	var timeout null.Duration
+	var since null.Date
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.TextVar(&limit, "limit", null.NullInt, "")
+	fs.TextVar(&timeout, "timeout", null.Duration(time.Minute), "")
+	fs.TextVar(&since, "since", null.NullDate, "")
+
+	err := fs.Parse([]string{"-limit", "10", "-since", "2026-10-17"})
+	assert.NoError(t, err)
+	assert.Equal(t, null.Int(10), limit)
+	assert.Equal(t, null.Duration(time.Minute), timeout)
+	assert.Equal(t, null.Date{Year: 2026, Month: 10, Day: 17}, since)
+}
@@ -0,0 +1,240 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// Time is a time.Time that will write as null when it is zero, both to databases and JSON
+// null values when unmarshalled or scanned from a DB will result in a zero time value.
+type Time struct {
+	time.Time
+}
+
+// NullTime is our constant for a Time value that will be written as null
+var NullTime = Time{}
+
+// Scan implements the Scanner interface
+func (t *Time) Scan(value any) error { return ScanTime(value, t) }
+
+// Value implements the Valuer interface
+func (t Time) Value() (driver.Value, error) { return TimeValue(t) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (t *Time) UnmarshalJSON(b []byte) error { return UnmarshalTime(b, t) }
+
+// MarshalJSON implements the Marshaller interface
+func (t Time) MarshalJSON() ([]byte, error) { return MarshalTime(t) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (t *Time) UnmarshalText(b []byte) error { return UnmarshalTimeText(b, t) }
+
+// MarshalText implements the TextMarshaler interface
+func (t Time) MarshalText() ([]byte, error) { return MarshalTimeText(t) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (t *Time) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(t, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (t Time) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(t, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (t Time) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(t, name) }
+
+// AppendText implements the TextAppender interface, overriding the one promoted from time.Time
+func (t Time) AppendText(b []byte) ([]byte, error) {
+	text, err := MarshalTimeText(t)
+	return append(b, text...), err
+}
+
+// TimeUTC is like Time but normalizes values to UTC when they are scanned or unmarshalled, so that they can be compared
+// with == or assert.Equal.
+type TimeUTC struct {
+	time.Time
+}
+
+// NullTimeUTC is our constant for a TimeUTC value that will be written as null
+var NullTimeUTC = TimeUTC{}
+
+// Scan implements the Scanner interface
+func (t *TimeUTC) Scan(value any) error { return ScanTimeUTC(value, t) }
+
+// Value implements the Valuer interface
+func (t TimeUTC) Value() (driver.Value, error) { return TimeValue(t) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (t *TimeUTC) UnmarshalJSON(b []byte) error { return UnmarshalTimeUTC(b, t) }
+
+// MarshalJSON implements the Marshaller interface
+func (t TimeUTC) MarshalJSON() ([]byte, error) { return MarshalTime(t) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (t *TimeUTC) UnmarshalText(b []byte) error { return UnmarshalTimeUTCText(b, t) }
+
+// MarshalText implements the TextMarshaler interface
+func (t TimeUTC) MarshalText() ([]byte, error) { return MarshalTimeText(t) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (t *TimeUTC) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(t, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (t TimeUTC) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(t, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (t TimeUTC) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(t, name) }
+
+// AppendText implements the TextAppender interface, overriding the one promoted from time.Time
+func (t TimeUTC) AppendText(b []byte) ([]byte, error) {
+	text, err := MarshalTimeText(t)
+	return append(b, text...), err
+}
+
+// TimeType is the constraint for custom time types, which should be defined as structs embedding time.Time, e.g.
+//
+//	type CreatedOn struct{ time.Time }
+type TimeType interface {
+	~struct{ time.Time }
+}
+
+// ScanTime scans a nullable TIMESTAMP into a time type, using the zero time for NULL. RFC 3339 text values are also
+// accepted for drivers that return timestamps as text.
+func ScanTime[T TimeType](value any, t *T) error {
+	var tm time.Time
+
+	switch typed := value.(type) {
+	case nil:
+	case time.Time:
+		tm = typed
+	case string:
+		return scanTimeText(typed, t)
+	case []byte:
+		return scanTimeText(string(typed), t)
+	default:
+		return fmt.Errorf("unable to scan %T as time", value)
+	}
+
+	*t = T{tm}
+	return nil
+}
+
+// ScanTimeUTC is like ScanTime but normalizes the scanned value to UTC, which is useful because drivers like lib/pq
+// can return times with a fixed zone location which won't compare equal to the same time in UTC.
+func ScanTimeUTC[T TimeType](value any, t *T) error {
+	if err := ScanTime(value, t); err != nil {
+		return err
+	}
+
+	toUTC(t)
+	return nil
+}
+
+// normalizes a non-zero time type value to UTC
+func toUTC[T TimeType](t *T) {
+	if tm := struct{ time.Time }(*t).Time; !tm.IsZero() {
+		*t = T{tm.UTC()}
+	}
+}
+
+func scanTimeText[T TimeType](s string, t *T) error {
+	if s == "" {
+		*t = T{}
+		return nil
+	}
+
+	tm, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return fmt.Errorf("unable to scan %q as time: %w", s, err)
+	}
+
+	*t = T{tm}
+	return nil
+}
+
+// TimeValue converts a time type value to NULL if it is zero.
+func TimeValue[T TimeType](t T) (driver.Value, error) {
+	tm := struct{ time.Time }(t).Time
+	if tm.IsZero() {
+		return nil, nil
+	}
+	return tm, nil
+}
+
+// UnmarshalTime unmarshals a time type from JSON, using the zero time for null.
+func UnmarshalTime[T TimeType](b []byte, t *T) error {
+	var val *time.Time
+
+	if err := json.Unmarshal(b, &val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		*t = T{}
+		return nil
+	}
+
+	*t = T{*val}
+	return nil
+}
+
+// UnmarshalTimeUTC is like UnmarshalTime but normalizes the unmarshalled value to UTC.
+func UnmarshalTimeUTC[T TimeType](b []byte, t *T) error {
+	if err := UnmarshalTime(b, t); err != nil {
+		return err
+	}
+
+	toUTC(t)
+	return nil
+}
+
+// MarshalTime marshals a time type to JSON, using null for the zero time.
+func MarshalTime[T TimeType](t T) ([]byte, error) {
+	tm := struct{ time.Time }(t).Time
+	if tm.IsZero() {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(tm)
+}
+
+// UnmarshalTimeText unmarshals a time type from RFC 3339 text, using the zero time for empty text.
+func UnmarshalTimeText[T TimeType](b []byte, t *T) error {
+	var tm time.Time
+
+	if len(b) > 0 {
+		if err := tm.UnmarshalText(b); err != nil {
+			return err
+		}
+	}
+
+	*t = T{tm}
+	return nil
+}
+
+// UnmarshalTimeUTCText is like UnmarshalTimeText but normalizes the unmarshalled value to UTC.
+func UnmarshalTimeUTCText[T TimeType](b []byte, t *T) error {
+	if err := UnmarshalTimeText(b, t); err != nil {
+		return err
+	}
+
+	toUTC(t)
+	return nil
+}
+
+// MarshalTimeText marshals a time type to RFC 3339 text, using empty text for the zero time.
+func MarshalTimeText[T TimeType](t T) ([]byte, error) {
+	tm := struct{ time.Time }(t).Time
+	if tm.IsZero() {
+		return []byte{}, nil
+	}
+	return tm.MarshalText()
+}
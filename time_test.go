@@ -0,0 +1,223 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTime(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value TIMESTAMPTZ NULL);`)
+
+	t1 := time.Date(2026, 10, 17, 13, 30, 15, 123456000, time.UTC)
+
+	tcs := []struct {
+		value     null.Time
+		dbValue   driver.Value
+		marshaled []byte
+	}{
+		{null.Time{Time: t1}, t1, []byte(`"2026-10-17T13:30:15.123456Z"`)},
+		{null.NullTime, nil, []byte(`null`)},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		var scanned null.Time
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		// driver may give us a different location so compare instants
+		assert.True(t, tc.value.Equal(scanned.Time), "scanned value mismatch for %v", tc.value)
+
+		marshaled, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+		var unmarshaled null.Time
+		err = json.Unmarshal(marshaled, &unmarshaled)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+func TestTimeUTC(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value TIMESTAMPTZ NULL);`)
+
+	t1 := time.Date(2026, 10, 17, 13, 30, 15, 123456000, time.UTC)
+
+	tcs := []struct {
+		value     null.TimeUTC
+		dbValue   driver.Value
+		marshaled []byte
+	}{
+		{null.TimeUTC{Time: t1}, t1, []byte(`"2026-10-17T13:30:15.123456Z"`)},
+		{null.NullTimeUTC, nil, []byte(`null`)},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		var scanned null.TimeUTC
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tc.value, scanned, "scanned value mismatch for %v", tc.value)
+
+		marshaled, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+		var unmarshaled null.TimeUTC
+		err = json.Unmarshal(marshaled, &unmarshaled)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+func TestTimeUTCNormalization(t *testing.T) {
+	t1 := null.TimeUTC{Time: time.Date(2026, 10, 17, 13, 30, 15, 0, time.UTC)}
+	est := time.FixedZone("EST", -5*60*60)
+
+	var tm null.TimeUTC
+	assert.NoError(t, tm.Scan(t1.In(est)))
+	assert.Equal(t, t1, tm)
+	assert.NoError(t, tm.Scan("2026-10-17T08:30:15-05:00"))
+	assert.Equal(t, t1, tm)
+	assert.NoError(t, tm.Scan(nil))
+	assert.Equal(t, null.NullTimeUTC, tm)
+
+	assert.NoError(t, json.Unmarshal([]byte(`"2026-10-17T08:30:15-05:00"`), &tm))
+	assert.Equal(t, t1, tm)
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &tm))
+	assert.Equal(t, null.NullTimeUTC, tm)
+
+	assert.NoError(t, tm.UnmarshalText([]byte(`2026-10-17T19:00:15+05:30`)))
+	assert.Equal(t, t1, tm)
+	assert.NoError(t, tm.UnmarshalText([]byte(``)))
+	assert.Equal(t, null.NullTimeUTC, tm)
+
+	assert.EqualError(t, tm.Scan(123), "unable to scan int as time")
+	assert.Error(t, json.Unmarshal([]byte(`"yesterday"`), &tm))
+}
+
+type CustomTime struct{ time.Time }
+
+func (t *CustomTime) Scan(value any) error         { return null.ScanTimeUTC(value, t) }
+func (t CustomTime) Value() (driver.Value, error)  { return null.TimeValue(t) }
+func (t *CustomTime) UnmarshalJSON(b []byte) error { return null.UnmarshalTime(b, t) }
+func (t CustomTime) MarshalJSON() ([]byte, error)  { return null.MarshalTime(t) }
+
+func TestCustomTime(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value TIMESTAMPTZ NULL);`)
+
+	t1 := time.Date(2026, 10, 17, 13, 30, 15, 0, time.UTC)
+
+	tcs := []struct {
+		value     CustomTime
+		dbValue   driver.Value
+		marshaled []byte
+	}{
+		{CustomTime{t1}, t1, []byte(`"2026-10-17T13:30:15Z"`)},
+		{CustomTime{}, nil, []byte(`null`)},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		var scanned CustomTime
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tc.value, scanned, "scanned value mismatch for %v", tc.value)
+
+		marshaled, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+		var unmarshaled CustomTime
+		err = json.Unmarshal(marshaled, &unmarshaled)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+func TestScanTime(t *testing.T) {
+	t1 := time.Date(2026, 10, 17, 13, 30, 15, 0, time.UTC)
+	est := time.FixedZone("EST", -5*60*60)
+
+	tcs := []struct {
+		value    any
+		expected null.Time
+		utc      CustomTime
+	}{
+		{nil, null.NullTime, CustomTime{}},
+		{"", null.NullTime, CustomTime{}},
+		{t1, null.Time{Time: t1}, CustomTime{t1}},
+		{t1.In(est), null.Time{Time: t1.In(est)}, CustomTime{t1}},
+		{"2026-10-17T13:30:15Z", null.Time{Time: t1}, CustomTime{t1}},
+		{[]byte("2026-10-17T08:30:15-05:00"), null.Time{Time: time.Date(2026, 10, 17, 8, 30, 15, 0, time.FixedZone("", -5*60*60))}, CustomTime{t1}},
+	}
+
+	for _, tc := range tcs {
+		scanned := null.Time{Time: time.Now()}
+		err := scanned.Scan(tc.value)
+		assert.NoError(t, err, "unexpected error scanning %v", tc.value)
+		assert.True(t, tc.expected.Equal(scanned.Time), "scanned value mismatch for %v", tc.value)
+
+		utc := CustomTime{time.Now()}
+		err = utc.Scan(tc.value)
+		assert.NoError(t, err, "unexpected error scanning %v", tc.value)
+		assert.Equal(t, tc.utc, utc, "scanned UTC value mismatch for %v", tc.value)
+	}
+
+	var tm null.Time
+	assert.EqualError(t, tm.Scan(123), "unable to scan int as time")
+	assert.Error(t, tm.Scan("yesterday"))
+}
@@ -0,0 +1,222 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Uint is a uint that will write as null when it is zero both to databases and JSON
+// null values when unmarshalled or scanned from a DB will result in a zero value.
+type Uint uint
+
+// NullUint is our constant for a Uint value that will be written as null
+const NullUint = Uint(0)
+
+// IsZero returns whether this is the zero value
+func (u Uint) IsZero() bool { return u == NullUint }
+
+// Scan implements the Scanner interface
+func (u *Uint) Scan(value any) error { return ScanUint(value, u) }
+
+// Value implements the Valuer interface
+func (u Uint) Value() (driver.Value, error) { return UintValue(u) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (u *Uint) UnmarshalJSON(b []byte) error { return UnmarshalUint(b, u) }
+
+// MarshalJSON implements the Marshaller interface
+func (u Uint) MarshalJSON() ([]byte, error) { return MarshalUint(u) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (u *Uint) UnmarshalText(b []byte) error { return UnmarshalUintText(b, u) }
+
+// MarshalText implements the TextMarshaler interface
+func (u Uint) MarshalText() ([]byte, error) { return MarshalUintText(u) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (u *Uint) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(u, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (u Uint) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(u, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (u Uint) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(u, name) }
+
+// Uint64 is a uint64 that will write as null when it is zero both to databases and JSON
+// null values when unmarshalled or scanned from a DB will result in a zero value.
+type Uint64 uint64
+
+// NullUint64 is our constant for a Uint64 value that will be written as null
+const NullUint64 = Uint64(0)
+
+// IsZero returns whether this is the zero value
+func (u Uint64) IsZero() bool { return u == NullUint64 }
+
+// Scan implements the Scanner interface
+func (u *Uint64) Scan(value any) error { return ScanUint(value, u) }
+
+// Value implements the Valuer interface
+func (u Uint64) Value() (driver.Value, error) { return UintValue(u) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (u *Uint64) UnmarshalJSON(b []byte) error { return UnmarshalUint(b, u) }
+
+// MarshalJSON implements the Marshaller interface
+func (u Uint64) MarshalJSON() ([]byte, error) { return MarshalUint(u) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (u *Uint64) UnmarshalText(b []byte) error { return UnmarshalUintText(b, u) }
+
+// MarshalText implements the TextMarshaler interface
+func (u Uint64) MarshalText() ([]byte, error) { return MarshalUintText(u) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (u *Uint64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(u, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (u Uint64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(u, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (u Uint64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(u, name) }
+
+// ScanUint scans a nullable INT/NUMERIC into an unsigned int type, using zero for NULL. Text values are parsed
+// as decimal numbers so that values larger than math.MaxInt64 can be scanned from NUMERIC columns.
+func ScanUint[T constraints.Unsigned](value any, u *T) error {
+	var v uint64
+
+	switch typed := value.(type) {
+	case nil:
+	case int64:
+		if typed < 0 {
+			return &RangeError{Value: typed, Type: reflect.TypeOf(*u)}
+		}
+		v = uint64(typed)
+	case uint64:
+		v = typed
+	case string:
+		return scanUintText(typed, u)
+	case []byte:
+		return scanUintText(string(typed), u)
+	default:
+		return fmt.Errorf("unable to scan %T as unsigned int", value)
+	}
+
+	return convertUint(v, u)
+}
+
+func scanUintText[T constraints.Unsigned](s string, u *T) error {
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		if isNegativeInt(s) {
+			return &RangeError{Value: s, Type: reflect.TypeOf(*u)}
+		}
+		return fmt.Errorf("unable to scan %q as unsigned int: %w", s, err)
+	}
+
+	return convertUint(v, u)
+}
+
+// returns whether s is a negative decimal integer, which is out of range for any unsigned int type
+func isNegativeInt(s string) bool {
+	if len(s) < 2 || s[0] != '-' {
+		return false
+	}
+	for _, c := range s[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return strings.Trim(s[1:], "0") != ""
+}
+
+// sets u to v after checking that it fits in the unsigned int type
+func convertUint[T constraints.Unsigned](v uint64, u *T) error {
+	if uint64(T(v)) != v {
+		return &RangeError{Value: v, Type: reflect.TypeOf(*u)}
+	}
+
+	*u = T(v)
+	return nil
+}
+
+// UintValue converts an unsigned int type value to NULL if it is zero. Values which don't fit in an int64 are
+// written as decimal strings, which can be stored in a NUMERIC column.
+func UintValue[T constraints.Unsigned](u T) (driver.Value, error) {
+	if u == 0 {
+		return nil, nil
+	}
+	if uint64(u) > math.MaxInt64 {
+		return strconv.FormatUint(uint64(u), 10), nil
+	}
+	return int64(u), nil
+}
+
+// UnmarshalUint unmarshals an unsigned int type from JSON, using zero for null.
+func UnmarshalUint[T constraints.Unsigned](b []byte, u *T) error {
+	if isJSONInt(b) && isNegativeInt(string(b)) {
+		return &RangeError{Value: string(b), Type: reflect.TypeOf(*u)}
+	}
+
+	var val *uint64
+
+	if err := json.Unmarshal(b, &val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		*u = 0
+		return nil
+	}
+
+	return convertUint(*val, u)
+}
+
+// MarshalUint marshals an unsigned int type to JSON, using null for zero.
+func MarshalUint[T constraints.Unsigned](u T) ([]byte, error) {
+	if u == 0 {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(uint64(u))
+}
+
+// UnmarshalUintText unmarshals an unsigned int type from text, using zero for empty text.
+func UnmarshalUintText[T constraints.Unsigned](b []byte, u *T) error {
+	if len(b) == 0 {
+		*u = 0
+		return nil
+	}
+
+	v, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		if isNegativeInt(string(b)) {
+			return &RangeError{Value: string(b), Type: reflect.TypeOf(*u)}
+		}
+		return fmt.Errorf("unable to unmarshal %q as unsigned int: %w", b, err)
+	}
+
+	return convertUint(v, u)
+}
+
+// MarshalUintText marshals an unsigned int type to text, using empty text for zero.
+func MarshalUintText[T constraints.Unsigned](u T) ([]byte, error) {
+	if u == 0 {
+		return []byte{}, nil
+	}
+	return strconv.AppendUint(nil, uint64(u), 10), nil
+}
@@ -0,0 +1,191 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUint(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value VARCHAR(255) NULL);`)
+
+	tcs := []struct {
+		value     null.Uint
+		dbValue   driver.Value
+		marshaled []byte
+	}{
+		{null.Uint(123), int64(123), []byte(`123`)},
+		{null.NullUint, nil, []byte(`null`)},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		var scanned null.Uint
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tc.value, scanned, "scanned value mismatch for %v", tc.value)
+
+		marshaled, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+		var unmarshaled null.Uint
+		err = json.Unmarshal(marshaled, &unmarshaled)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+func TestUint64(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value NUMERIC(20) NULL);`)
+
+	tcs := []struct {
+		value     null.Uint64
+		dbValue   driver.Value
+		marshaled []byte
+	}{
+		{null.Uint64(123), int64(123), []byte(`123`)},
+		{null.Uint64(math.MaxInt64), int64(math.MaxInt64), []byte(`9223372036854775807`)},
+		{null.Uint64(math.MaxInt64 + 1), "9223372036854775808", []byte(`9223372036854775808`)},
+		{null.Uint64(math.MaxUint64), "18446744073709551615", []byte(`18446744073709551615`)},
+		{null.NullUint64, nil, []byte(`null`)},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		var scanned null.Uint64
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tc.value, scanned, "scanned value mismatch for %v", tc.value)
+
+		marshaled, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+		var unmarshaled null.Uint64
+		err = json.Unmarshal(marshaled, &unmarshaled)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+type CustomUID uint32
+
+func (u *CustomUID) Scan(value any) error         { return null.ScanUint(value, u) }
+func (u CustomUID) Value() (driver.Value, error)  { return null.UintValue(u) }
+func (u *CustomUID) UnmarshalJSON(b []byte) error { return null.UnmarshalUint(b, u) }
+func (u CustomUID) MarshalJSON() ([]byte, error)  { return null.MarshalUint(u) }
+
+func TestScanUint(t *testing.T) {
+	tcs := []struct {
+		value    any
+		expected CustomUID
+	}{
+		{nil, 0},
+		{int64(0), 0},
+		{int64(123), 123},
+		{uint64(123), 123},
+		{"123", 123},
+		{[]byte("4294967295"), math.MaxUint32},
+	}
+
+	for _, tc := range tcs {
+		scanned := CustomUID(1)
+		err := scanned.Scan(tc.value)
+		assert.NoError(t, err, "unexpected error scanning %v", tc.value)
+		assert.Equal(t, tc.expected, scanned, "scanned value mismatch for %v", tc.value)
+	}
+
+	var u CustomUID
+	assert.EqualError(t, u.Scan(int64(-1)), "value -1 is out of range for null_test.CustomUID")
+	assert.EqualError(t, u.Scan(1.5), "unable to scan float64 as unsigned int")
+	assert.Error(t, u.Scan("-1"))
+	assert.Error(t, u.Scan("abc"))
+
+	assert.Error(t, json.Unmarshal([]byte(`-1`), &u))
+}
+
+func TestUintOverflow(t *testing.T) {
+	u := CustomUID(1)
+
+	err := u.Scan(int64(math.MaxUint32 + 1))
+	assert.EqualError(t, err, "value 4294967296 is out of range for null_test.CustomUID")
+	assert.Equal(t, CustomUID(1), u) // unchanged
+
+	var rangeErr *null.RangeError
+	if assert.ErrorAs(t, err, &rangeErr) {
+		assert.Equal(t, uint64(math.MaxUint32+1), rangeErr.Value)
+		assert.Equal(t, reflect.TypeOf(CustomUID(0)), rangeErr.Type)
+	}
+
+	assert.EqualError(t, u.Scan("18446744073709551615"), "value 18446744073709551615 is out of range for null_test.CustomUID")
+	assert.EqualError(t, json.Unmarshal([]byte(`4294967296`), &u), "value 4294967296 is out of range for null_test.CustomUID")
+
+	// negative values are also out of range
+	err = u.Scan(int64(-1))
+	if assert.ErrorAs(t, err, &rangeErr) {
+		assert.Equal(t, int64(-1), rangeErr.Value)
+		assert.Equal(t, reflect.TypeOf(CustomUID(0)), rangeErr.Type)
+	}
+	assert.Equal(t, CustomUID(1), u) // unchanged
+
+	assert.ErrorAs(t, u.Scan("-5"), &rangeErr)
+	assert.EqualError(t, u.Scan([]byte("-5")), "value -5 is out of range for null_test.CustomUID")
+	assert.EqualError(t, json.Unmarshal([]byte(`-5`), &u), "value -5 is out of range for null_test.CustomUID")
+	assert.EqualError(t, null.UnmarshalUintText([]byte(`-5`), &u), "value -5 is out of range for null_test.CustomUID")
+	assert.False(t, errors.As(u.Scan("-abc"), &rangeErr))
+	assert.False(t, errors.As(json.Unmarshal([]byte(`-1.5`), &u), &rangeErr))
+
+	// as are they for generic values, reported for their own type
+	v := null.Value[uint8]{V: 3}
+	err = v.Scan(int64(-1))
+	assert.EqualError(t, err, "value -1 is out of range for uint8")
+	assert.ErrorAs(t, err, &rangeErr)
+	assert.EqualError(t, v.Scan("-1"), "value -1 is out of range for uint8")
+	assert.EqualError(t, json.Unmarshal([]byte(`-1`), &v), "value -1 is out of range for uint8")
+	assert.EqualError(t, v.UnmarshalText([]byte(`-1`)), "value -1 is out of range for uint8")
+	assert.Equal(t, uint8(3), v.V)
+
+	var u8 uint8
+	assert.NoError(t, null.UnmarshalUint([]byte(`255`), &u8))
+	assert.Equal(t, uint8(255), u8)
+	assert.EqualError(t, null.UnmarshalUint([]byte(`256`), &u8), "value 256 is out of range for uint8")
+}
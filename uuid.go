@@ -0,0 +1,178 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+)
+
+// UUID is a UUID that will write as null when it is all zeros, both to databases and JSON
+// null values when unmarshalled or scanned from a DB will result in a zero UUID.
+type UUID [16]byte
+
+// NullUUID is our constant for a UUID value that will be written as null
+var NullUUID = UUID{}
+
+// ParseUUID parses a UUID in the canonical hyphenated form, e.g. 9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d, case insensitively.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return NullUUID, fmt.Errorf("invalid UUID %q", s)
+	}
+
+	h := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	if _, err := hex.Decode(u[:], []byte(h)); err != nil {
+		return NullUUID, fmt.Errorf("invalid UUID %q", s)
+	}
+
+	return u, nil
+}
+
+// String returns this UUID in the canonical lowercase hyphenated form.
+func (u UUID) String() string {
+	b := make([]byte, 36)
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:36], u[10:16])
+	return string(b)
+}
+
+// IsZero returns whether this is the zero value
+func (u UUID) IsZero() bool { return u == NullUUID }
+
+// Scan implements the Scanner interface
+func (u *UUID) Scan(value any) error { return ScanUUID(value, u) }
+
+// Value implements the Valuer interface
+func (u UUID) Value() (driver.Value, error) { return UUIDValue(u) }
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (u *UUID) UnmarshalJSON(b []byte) error { return UnmarshalUUID(b, u) }
+
+// MarshalJSON implements the Marshaller interface
+func (u UUID) MarshalJSON() ([]byte, error) { return MarshalUUID(u) }
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (u *UUID) UnmarshalText(b []byte) error { return UnmarshalUUIDText(b, u) }
+
+// MarshalText implements the TextMarshaler interface
+func (u UUID) MarshalText() ([]byte, error) { return MarshalUUIDText(u) }
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (u *UUID) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(u, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (u UUID) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(u, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (u UUID) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(u, name) }
+
+// ScanUUID scans a nullable UUID into a UUID type, using the zero UUID for NULL. Values can be text or 16 bytes of
+// binary data.
+func ScanUUID[T ~[16]byte](value any, u *T) error {
+	switch typed := value.(type) {
+	case nil:
+		*u = T{}
+		return nil
+	case string:
+		return scanUUIDText(typed, u)
+	case []byte:
+		if len(typed) == 16 {
+			*u = T(*(*[16]byte)(typed))
+			return nil
+		}
+		return scanUUIDText(string(typed), u)
+	default:
+		return fmt.Errorf("unable to scan %T as UUID", value)
+	}
+}
+
+func scanUUIDText[T ~[16]byte](s string, u *T) error {
+	if s == "" {
+		*u = T{}
+		return nil
+	}
+
+	parsed, err := ParseUUID(s)
+	if err != nil {
+		return err
+	}
+
+	*u = T(parsed)
+	return nil
+}
+
+// UUIDValue converts a UUID type value to NULL if it is zero, otherwise it is written as canonical text.
+func UUIDValue[T ~[16]byte](u T) (driver.Value, error) {
+	if u == (T{}) {
+		return nil, nil
+	}
+	return UUID(u).String(), nil
+}
+
+// UnmarshalUUID unmarshals a UUID type from JSON, using the zero UUID for null.
+func UnmarshalUUID[T ~[16]byte](b []byte, u *T) error {
+	var val *string
+
+	if err := json.Unmarshal(b, &val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		*u = T{}
+		return nil
+	}
+
+	parsed, err := ParseUUID(*val)
+	if err != nil {
+		return err
+	}
+
+	*u = T(parsed)
+	return nil
+}
+
+// MarshalUUID marshals a UUID type to JSON, using null for the zero UUID.
+func MarshalUUID[T ~[16]byte](u T) ([]byte, error) {
+	if u == (T{}) {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(UUID(u).String())
+}
+
+// UnmarshalUUIDText unmarshals a UUID type from canonical text, using the zero UUID for empty text.
+func UnmarshalUUIDText[T ~[16]byte](b []byte, u *T) error {
+	if len(b) == 0 {
+		*u = T{}
+		return nil
+	}
+
+	parsed, err := ParseUUID(string(b))
+	if err != nil {
+		return err
+	}
+
+	*u = T(parsed)
+	return nil
+}
+
+// MarshalUUIDText marshals a UUID type to canonical text, using empty text for the zero UUID.
+func MarshalUUIDText[T ~[16]byte](u T) ([]byte, error) {
+	if u == (T{}) {
+		return []byte{}, nil
+	}
+	return []byte(UUID(u).String()), nil
+}
@@ -0,0 +1,112 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUUID(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(value UUID NULL);`)
+
+	u1 := null.UUID{0x9b, 0x7b, 0x6b, 0x8a, 0x8e, 0x3c, 0x4f, 0x9a, 0x9a, 0x43, 0x1d, 0x8b, 0x5a, 0x1f, 0x2c, 0x3d}
+
+	tcs := []struct {
+		value     null.UUID
+		dbValue   driver.Value
+		marshaled []byte
+	}{
+		{u1, "9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d", []byte(`"9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d"`)},
+		{null.NullUUID, nil, []byte(`null`)},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbValue, err := tc.value.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbValue, dbValue, "db value mismatch for %v", tc.value)
+
+		// check writing the value to the database
+		_, err = db.Exec(`INSERT INTO test(value) VALUES($1)`, tc.value)
+		assert.NoError(t, err, "unexpected error writing %v", tc.value)
+
+		rows, err := db.Query(`SELECT value FROM test;`)
+		assert.NoError(t, err)
+
+		var scanned null.UUID
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scanned)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tc.value, scanned, "scanned value mismatch for %v", tc.value)
+
+		marshaled, err := json.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, marshaled, "marshaled mismatch for %v", tc.value)
+
+		var unmarshaled null.UUID
+		err = json.Unmarshal(marshaled, &unmarshaled)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+type CustomUUID [16]byte
+
+func (u *CustomUUID) Scan(value any) error         { return null.ScanUUID(value, u) }
+func (u CustomUUID) Value() (driver.Value, error)  { return null.UUIDValue(u) }
+func (u *CustomUUID) UnmarshalJSON(b []byte) error { return null.UnmarshalUUID(b, u) }
+func (u CustomUUID) MarshalJSON() ([]byte, error)  { return null.MarshalUUID(u) }
+
+func TestScanUUID(t *testing.T) {
+	u1 := CustomUUID{0x9b, 0x7b, 0x6b, 0x8a, 0x8e, 0x3c, 0x4f, 0x9a, 0x9a, 0x43, 0x1d, 0x8b, 0x5a, 0x1f, 0x2c, 0x3d}
+
+	tcs := []struct {
+		value    any
+		expected CustomUUID
+	}{
+		{nil, CustomUUID{}},
+		{"", CustomUUID{}},
+		{"9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d", u1},
+		{"9B7B6B8A-8E3C-4F9A-9A43-1D8B5A1F2C3D", u1},
+		{[]byte("9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d"), u1},
+		{u1[:], u1},
+		{"00000000-0000-0000-0000-000000000000", CustomUUID{}},
+	}
+
+	for _, tc := range tcs {
+		scanned := CustomUUID{1}
+		err := scanned.Scan(tc.value)
+		assert.NoError(t, err, "unexpected error scanning %v", tc.value)
+		assert.Equal(t, tc.expected, scanned, "scanned value mismatch for %v", tc.value)
+	}
+
+	// binary data is copied
+	raw := append([]byte{}, u1[:]...)
+	var u CustomUUID
+	assert.NoError(t, u.Scan(raw))
+	raw[0] = 0
+	assert.Equal(t, u1, u)
+
+	assert.EqualError(t, u.Scan(123), "unable to scan int as UUID")
+	assert.EqualError(t, u.Scan("9b7b6b8a8e3c4f9a9a431d8b5a1f2c3d"), `invalid UUID "9b7b6b8a8e3c4f9a9a431d8b5a1f2c3d"`)
+	assert.EqualError(t, u.Scan("9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3z"), `invalid UUID "9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3z"`)
+	assert.Error(t, u.Scan([]byte{1, 2, 3}))
+
+	// JSON is validated
+	assert.NoError(t, json.Unmarshal([]byte(`"9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d"`), &u))
+	assert.Equal(t, u1, u)
+	assert.EqualError(t, json.Unmarshal([]byte(`"foo"`), &u), `invalid UUID "foo"`)
+	assert.Error(t, json.Unmarshal([]byte(`123`), &u))
+
+	// and always written as lowercase
+	v, err := u.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d", v)
+}
@@ -0,0 +1,368 @@
+package null
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Value wraps any comparable type so that it will write as null when it is the zero value of that type, both to
+// databases and JSON. null values when unmarshalled or scanned from a DB will result in the zero value. This means
+// custom types don't need their own methods, e.g.
+//
+//	type ContactID int64
+//
+//	type Contact struct {
+//	    ID     ContactID               `json:"id"`
+//	    Parent null.Value[ContactID] `json:"parent"`
+//	}
+//
+// Types with an underlying int, uint, float, bool or string type are handled by the same helpers as the other types in
+// this package, and types which implement sql.Scanner, driver.Valuer or the JSON interfaces use those. Byte arrays are
+// written to the database as BYTEA and other arrays as JSON, and types which the driver supports itself, e.g.
+// time.Time, are passed through as is. Other types, e.g. structs, can only be used with JSON.
+type Value[T comparable] struct {
+	V T
+}
+
+// IsNull returns whether this value is the zero value of its type.
+func (v Value[T]) IsNull() bool { return IsZero(v.V) }
+
+// IsZero returns whether the given value is the zero value of its type. Custom types can use this to implement an
+// IsZero method so that they can be omitted with the omitzero JSON tag.
+func IsZero[T comparable](v T) bool {
+	var zero T
+	return v == zero
+}
+
+// IsZero returns whether this is the zero value
+func (v Value[T]) IsZero() bool { return v.IsNull() }
+
+// Scan implements the Scanner interface
+func (v *Value[T]) Scan(value any) error { return scanInto(value, &v.V) }
+
+// scans a value into what dst points to, using its Scan method if it has one or the helper for its underlying kind
+func scanInto(value any, dst any) error {
+	if s, ok := dst.(sql.Scanner); ok {
+		return s.Scan(value)
+	}
+
+	rv := reflect.ValueOf(dst).Elem()
+
+	// values which the driver already gives us as the right type, e.g. time.Time, can be used as is
+	if value != nil && reflect.TypeOf(value).AssignableTo(rv.Type()) {
+		rv.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if err := ScanInt(value, &i); err != nil {
+			return withRangeType(err, rv)
+		}
+		return setReflectInt(rv, i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		if err := ScanUint(value, &u); err != nil {
+			return withRangeType(err, rv)
+		}
+		return setReflectUint(rv, u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if err := ScanFloat(value, &f); err != nil {
+			return withRangeType(err, rv)
+		}
+		return setReflectFloat(rv, f)
+	case reflect.Bool:
+		var b bool
+		if err := ScanBool(value, &b); err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	case reflect.String:
+		var s string
+		if err := ScanString(value, &s); err != nil {
+			return err
+		}
+		rv.SetString(s)
+	case reflect.Array:
+		return scanArrayInto(value, rv)
+	default:
+		if value != nil {
+			return fmt.Errorf("unable to scan %T as %s", value, rv.Type())
+		}
+		rv.Set(reflect.Zero(rv.Type()))
+	}
+	return nil
+}
+
+// Value implements the Valuer interface
+func (v Value[T]) Value() (driver.Value, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+	if valuer, ok := any(v.V).(driver.Valuer); ok {
+		return valuer.Value()
+	}
+
+	rv := reflect.ValueOf(v.V)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return IntValue(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return UintValue(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return FloatValue(rv.Float())
+	case reflect.Bool:
+		return BoolValue(rv.Bool())
+	case reflect.String:
+		return StringValue(rv.String())
+	case reflect.Array:
+		return arrayValue(rv)
+	default:
+		return driver.DefaultParameterConverter.ConvertValue(v.V)
+	}
+}
+
+// scans a BYTEA into a byte array of the same length, or JSON text into any other array
+func scanArrayInto(value any, rv reflect.Value) error {
+	var raw []byte
+	switch typed := value.(type) {
+	case nil:
+	case string:
+		raw = []byte(typed)
+	case []byte:
+		raw = typed
+	default:
+		return fmt.Errorf("unable to scan %T as %s", value, rv.Type())
+	}
+
+	if len(raw) == 0 {
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	}
+
+	if rv.Type().Elem().Kind() == reflect.Uint8 {
+		if len(raw) != rv.Len() {
+			return fmt.Errorf("unable to scan %d bytes as %s", len(raw), rv.Type())
+		}
+		for i, c := range raw {
+			rv.Index(i).SetUint(uint64(c))
+		}
+		return nil
+	}
+
+	// unmarshal into a new array so that elements missing from the JSON aren't kept from the previous value
+	arr := reflect.New(rv.Type())
+	if err := json.Unmarshal(raw, arr.Interface()); err != nil {
+		return err
+	}
+	rv.Set(arr.Elem())
+	return nil
+}
+
+// converts a byte array to BYTEA, or any other array to JSON
+func arrayValue(rv reflect.Value) (driver.Value, error) {
+	if rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		for i := range b {
+			b[i] = byte(rv.Index(i).Uint())
+		}
+		return b, nil
+	}
+	return json.Marshal(rv.Interface())
+}
+
+// UnmarshalJSON implements the Unmarshaller interface
+func (v *Value[T]) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(bytes.TrimSpace(b), []byte(`null`)) {
+		var zero T
+		v.V = zero
+		return nil
+	}
+
+	rv := reflect.ValueOf(&v.V).Elem()
+
+	if _, ok := any(&v.V).(json.Unmarshaler); !ok {
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			var i int64
+			if err := UnmarshalInt(b, &i); err != nil {
+				return withRangeType(err, rv)
+			}
+			return setReflectInt(rv, i)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			var u uint64
+			if err := UnmarshalUint(b, &u); err != nil {
+				return withRangeType(err, rv)
+			}
+			return setReflectUint(rv, u)
+		case reflect.Float32, reflect.Float64:
+			var f float64
+			if err := UnmarshalFloat(b, &f); err != nil {
+				return withRangeType(err, rv)
+			}
+			return setReflectFloat(rv, f)
+		}
+	}
+
+	return json.Unmarshal(b, &v.V)
+}
+
+// MarshalJSON implements the Marshaller interface
+func (v Value[T]) MarshalJSON() ([]byte, error) {
+	if v.IsNull() {
+		return json.Marshal(nil)
+	}
+
+	if _, ok := any(v.V).(json.Marshaler); !ok {
+		rv := reflect.ValueOf(v.V)
+
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return MarshalInt(rv.Int())
+		case reflect.String:
+			return MarshalString(rv.String())
+		}
+	}
+
+	return json.Marshal(v.V)
+}
+
+// UnmarshalText implements the TextUnmarshaler interface
+func (v *Value[T]) UnmarshalText(b []byte) error { return unmarshalTextInto(b, &v.V) }
+
+// MarshalText implements the TextMarshaler interface
+func (v Value[T]) MarshalText() ([]byte, error) {
+	if v.IsNull() {
+		return []byte{}, nil
+	}
+	return marshalTextOf(v.V)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface
+func (v *Value[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return UnmarshalXML(v, d, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface
+func (v Value[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return MarshalXML(v, e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface
+func (v Value[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) { return MarshalXMLAttr(v, name) }
+
+// unmarshals text into what dst points to, using its UnmarshalText method if it has one or the helper for its
+// underlying kind
+func unmarshalTextInto(b []byte, dst any) error {
+	if u, ok := dst.(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText(b)
+	}
+
+	rv := reflect.ValueOf(dst).Elem()
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if err := UnmarshalIntText(b, &i); err != nil {
+			return withRangeType(err, rv)
+		}
+		return setReflectInt(rv, i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		if err := UnmarshalUintText(b, &u); err != nil {
+			return withRangeType(err, rv)
+		}
+		return setReflectUint(rv, u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if err := UnmarshalFloatText(b, &f); err != nil {
+			return withRangeType(err, rv)
+		}
+		return setReflectFloat(rv, f)
+	case reflect.Bool:
+		var v bool
+		if err := UnmarshalBoolText(b, &v); err != nil {
+			return err
+		}
+		rv.SetBool(v)
+	case reflect.String:
+		rv.SetString(string(b))
+	default:
+		if len(b) > 0 {
+			return fmt.Errorf("unable to unmarshal text as %s", rv.Type())
+		}
+		rv.Set(reflect.Zero(rv.Type()))
+	}
+	return nil
+}
+
+// marshals v to text, using its MarshalText method if it has one or formatting it according to its underlying kind,
+// without treating zero values as null
+func marshalTextOf(v any) ([]byte, error) {
+	if m, ok := v.(encoding.TextMarshaler); ok {
+		return m.MarshalText()
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(nil, rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(nil, rv.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.AppendFloat(nil, rv.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.AppendFloat(nil, rv.Float(), 'g', -1, 64), nil
+	case reflect.Bool:
+		return strconv.AppendBool(nil, rv.Bool()), nil
+	case reflect.String:
+		return []byte(rv.String()), nil
+	default:
+		return nil, fmt.Errorf("unable to marshal %s as text", rv.Type())
+	}
+}
+
+func setReflectInt(rv reflect.Value, i int64) error {
+	if rv.OverflowInt(i) {
+		return &RangeError{Value: i, Type: rv.Type()}
+	}
+	rv.SetInt(i)
+	return nil
+}
+
+func setReflectUint(rv reflect.Value, u uint64) error {
+	if rv.OverflowUint(u) {
+		return &RangeError{Value: u, Type: rv.Type()}
+	}
+	rv.SetUint(u)
+	return nil
+}
+
+func setReflectFloat(rv reflect.Value, f float64) error {
+	if rv.OverflowFloat(f) {
+		return &RangeError{Value: f, Type: rv.Type()}
+	}
+	rv.SetFloat(f)
+	return nil
+}
+
+// reports a range error for the type of rv rather than for the int64, uint64 or float64 it was checked against
+func withRangeType(err error, rv reflect.Value) error {
+	var rangeErr *RangeError
+	if errors.As(err, &rangeErr) {
+		return &RangeError{Value: rangeErr.Value, Type: rv.Type()}
+	}
+	return err
+}
@@ -0,0 +1,184 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type ContactID int64
+
+type ContactName string
+
+func TestValue(t *testing.T) {
+	db := getTestDB()
+
+	mustExec(db, `DROP TABLE IF EXISTS test; CREATE TABLE test(id INT NULL, name VARCHAR(255) NULL);`)
+
+	tcs := []struct {
+		id            null.Value[ContactID]
+		name          null.Value[ContactName]
+		dbID          driver.Value
+		dbName        driver.Value
+		marshaledID   []byte
+		marshaledName []byte
+	}{
+		{null.Value[ContactID]{V: 123}, null.Value[ContactName]{V: "Bob"}, int64(123), "Bob", []byte(`123`), []byte(`"Bob"`)},
+		{null.Value[ContactID]{}, null.Value[ContactName]{}, nil, nil, []byte(`null`), []byte(`null`)},
+	}
+
+	for _, tc := range tcs {
+		mustExec(db, `DELETE FROM test`)
+
+		dbID, err := tc.id.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbID, dbID, "db value mismatch for %v", tc.id)
+
+		dbName, err := tc.name.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.dbName, dbName, "db value mismatch for %v", tc.name)
+
+		// check writing the values to the database
+		_, err = db.Exec(`INSERT INTO test(id, name) VALUES($1, $2)`, tc.id, tc.name)
+		assert.NoError(t, err, "unexpected error writing %v", tc.id)
+
+		rows, err := db.Query(`SELECT id, name FROM test;`)
+		assert.NoError(t, err)
+
+		var scannedID null.Value[ContactID]
+		var scannedName null.Value[ContactName]
+		assert.True(t, rows.Next())
+		err = rows.Scan(&scannedID, &scannedName)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tc.id, scannedID, "scanned value mismatch for %v", tc.id)
+		assert.Equal(t, tc.name, scannedName, "scanned value mismatch for %v", tc.name)
+
+		marshaled, err := json.Marshal(tc.id)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaledID, marshaled, "marshaled mismatch for %v", tc.id)
+
+		var unmarshaledID null.Value[ContactID]
+		err = json.Unmarshal(marshaled, &unmarshaledID)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.id, unmarshaledID, "unmarshaled mismatch for %v", tc.id)
+
+		marshaled, err = json.Marshal(tc.name)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaledName, marshaled, "marshaled mismatch for %v", tc.name)
+
+		var unmarshaledName null.Value[ContactName]
+		err = json.Unmarshal(marshaled, &unmarshaledName)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.name, unmarshaledName, "unmarshaled mismatch for %v", tc.name)
+	}
+}
+
+type coords struct {
+	Lat, Lng float64
+}
+
+func TestValueKinds(t *testing.T) {
+	// unsigned ints
+	u := null.Value[uint8]{V: 5}
+	assert.NoError(t, u.Scan(int64(255)))
+	assert.Equal(t, uint8(255), u.V)
+	assert.EqualError(t, u.Scan(int64(256)), "value 256 is out of range for uint8")
+	assert.EqualError(t, json.Unmarshal([]byte(`256`), &u), "value 256 is out of range for uint8")
+	assert.NoError(t, u.Scan(nil))
+	assert.True(t, u.IsNull())
+
+	// signed ints
+	i := null.Value[int16]{}
+	assert.EqualError(t, i.Scan(int64(40000)), "value 40000 is out of range for int16")
+	assert.NoError(t, json.Unmarshal([]byte(`-5`), &i))
+	assert.Equal(t, int16(-5), i.V)
+
+	// floats
+	f := null.Value[float32]{V: 1.5}
+	b, err := json.Marshal(f)
+	assert.NoError(t, err)
+	assert.Equal(t, `1.5`, string(b))
+	v, err := f.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1.5), v)
+	assert.NoError(t, f.Scan(nil))
+	assert.Equal(t, float32(0), f.V)
+
+	// bools
+	bl := null.Value[bool]{}
+	assert.NoError(t, bl.Scan("t"))
+	assert.True(t, bl.V)
+	v, err = null.Value[bool]{}.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	// types with their own methods use them
+	uuid := null.Value[null.UUID]{}
+	assert.NoError(t, uuid.Scan("9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d"))
+	assert.Equal(t, "9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d", uuid.V.String())
+	v, err = uuid.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d", v)
+	b, err = json.Marshal(uuid)
+	assert.NoError(t, err)
+	assert.Equal(t, `"9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d"`, string(b))
+	assert.EqualError(t, json.Unmarshal([]byte(`"foo"`), &uuid), `invalid UUID "foo"`)
+
+	// other types can still be used with JSON
+	c := null.Value[coords]{V: coords{1.5, 2.5}}
+	b, err = json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Lat":1.5,"Lng":2.5}`, string(b))
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &c))
+	assert.True(t, c.IsNull())
+	b, err = json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, `null`, string(b))
+
+	assert.NoError(t, c.Scan(nil))
+	assert.EqualError(t, c.Scan("foo"), "unable to scan string as null_test.coords")
+
+	// types which the driver supports are passed through as is
+	tm := null.Value[time.Time]{}
+	now := time.Date(2026, 10, 17, 12, 30, 0, 0, time.UTC)
+	assert.NoError(t, tm.Scan(now))
+	assert.Equal(t, now, tm.V)
+	v, err = tm.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, now, v)
+	assert.NoError(t, tm.Scan(nil))
+	assert.True(t, tm.IsNull())
+
+	// byte arrays are written as BYTEA
+	h := null.Value[hash]{V: hash{1, 2, 3, 4}}
+	v, err = h.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3, 4}, v)
+	h = null.Value[hash]{}
+	assert.NoError(t, h.Scan([]byte{5, 6, 7, 8}))
+	assert.Equal(t, hash{5, 6, 7, 8}, h.V)
+	assert.NoError(t, h.Scan(hash{9, 9, 9, 9}))
+	assert.Equal(t, hash{9, 9, 9, 9}, h.V)
+	assert.EqualError(t, h.Scan([]byte{1, 2}), "unable to scan 2 bytes as null_test.hash")
+	assert.NoError(t, h.Scan(nil))
+	assert.True(t, h.IsNull())
+	v, err = h.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	// and other arrays as JSON
+	a := null.Value[[3]int]{V: [3]int{1, 2, 3}}
+	v, err = a.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`[1,2,3]`), v)
+	assert.NoError(t, a.Scan(`[4,5]`))
+	assert.Equal(t, [3]int{4, 5, 0}, a.V)
+	assert.Error(t, a.Scan(`{}`))
+}
+
+type hash [4]byte
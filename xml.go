@@ -0,0 +1,69 @@
+package null
+
+import (
+	"encoding"
+	"encoding/xml"
+)
+
+// XSINamespace is the XML Schema instance namespace used for xsi:nil attributes
+const XSINamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// MarshalXML marshals a value to an XML element using its text form, writing an empty element with xsi:nil="true"
+// if the text is empty, i.e. if it is null.
+func MarshalXML(m encoding.TextMarshaler, e *xml.Encoder, start xml.StartElement) error {
+	text, err := m.MarshalText()
+	if err != nil {
+		return err
+	}
+
+	if len(text) == 0 {
+		start.Attr = append(start.Attr,
+			xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: XSINamespace},
+			xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+		)
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		return e.EncodeToken(start.End())
+	}
+
+	return e.EncodeElement(string(text), start)
+}
+
+// UnmarshalXML unmarshals a value from an XML element using its text form, treating an element with xsi:nil="true" as
+// null, i.e. the same as empty text.
+func UnmarshalXML(u encoding.TextUnmarshaler, d *xml.Decoder, start xml.StartElement) error {
+	if isXSINil(start) {
+		if err := d.Skip(); err != nil {
+			return err
+		}
+		return u.UnmarshalText([]byte{})
+	}
+
+	var text string
+	if err := d.DecodeElement(&text, &start); err != nil {
+		return err
+	}
+
+	return u.UnmarshalText([]byte(text))
+}
+
+// MarshalXMLAttr marshals a value to an XML attribute using its text form, omitting the attribute if the text is
+// empty, i.e. if it is null.
+func MarshalXMLAttr(m encoding.TextMarshaler, name xml.Name) (xml.Attr, error) {
+	text, err := m.MarshalText()
+	if err != nil || len(text) == 0 {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+func isXSINil(start xml.StartElement) bool {
+	for _, a := range start.Attr {
+		// if the xsi prefix wasn't declared then the decoder leaves it as the namespace
+		if a.Name.Local == "nil" && (a.Name.Space == XSINamespace || a.Name.Space == "xsi") {
+			return a.Value == "true" || a.Value == "1"
+		}
+	}
+	return false
+}
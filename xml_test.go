@@ -0,0 +1,130 @@
+package null_test
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/null/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type xmlContact struct {
+	XMLName  xml.Name         `xml:"contact"`
+	ID       null.Int64       `xml:"id,attr"`
+	UUID     null.UUID        `xml:"uuid,attr"`
+	Name     null.String      `xml:"name"`
+	Age      null.Int         `xml:"age"`
+	Balance  null.Float64     `xml:"balance"`
+	Active   null.Bool        `xml:"active"`
+	Birthday null.Date        `xml:"birthday"`
+	Created  null.Time        `xml:"created"`
+	Language null.Value[Lang] `xml:"language"`
+}
+
+type Lang string
+
+func TestXML(t *testing.T) {
+	u1 := null.UUID{0x9b, 0x7b, 0x6b, 0x8a, 0x8e, 0x3c, 0x4f, 0x9a, 0x9a, 0x43, 0x1d, 0x8b, 0x5a, 0x1f, 0x2c, 0x3d}
+	t1 := time.Date(2026, 10, 17, 13, 30, 15, 0, time.UTC)
+
+	nilAttrs := `xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"`
+
+	tcs := []struct {
+		value     xmlContact
+		marshaled string
+	}{
+		{
+			xmlContact{
+				ID: 123, UUID: u1, Name: "Bob", Age: 42, Balance: 1.5, Active: true,
+				Birthday: null.Date{Year: 1980, Month: 5, Day: 1}, Created: null.Time{Time: t1}, Language: null.Value[Lang]{V: "eng"},
+			},
+			`<contact id="123" uuid="9b7b6b8a-8e3c-4f9a-9a43-1d8b5a1f2c3d"><name>Bob</name><age>42</age><balance>1.5</balance>` +
+				`<active>true</active><birthday>1980-05-01</birthday><created>2026-10-17T13:30:15Z</created><language>eng</language></contact>`,
+		},
+		{
+			xmlContact{},
+			`<contact><name ` + nilAttrs + `></name><age ` + nilAttrs + `></age><balance ` + nilAttrs + `></balance>` +
+				`<active ` + nilAttrs + `></active><birthday ` + nilAttrs + `></birthday><created ` + nilAttrs + `></created>` +
+				`<language ` + nilAttrs + `></language></contact>`,
+		},
+	}
+
+	for _, tc := range tcs {
+		marshaled, err := xml.Marshal(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.marshaled, string(marshaled), "marshaled mismatch for %v", tc.value)
+
+		// unmarshal into a non-zero value to check nulls are applied
+		unmarshaled := xmlContact{ID: 1, UUID: null.UUID{1}, Name: "Jim", Age: 1, Balance: 1, Active: true}
+		err = xml.Unmarshal(marshaled, &unmarshaled)
+		assert.NoError(t, err)
+
+		expected := tc.value
+		expected.XMLName = xml.Name{Local: "contact"}
+		if expected.ID == 0 {
+			// missing attributes aren't unmarshaled at all
+			expected.ID = 1
+			expected.UUID = null.UUID{1}
+		}
+		assert.Equal(t, expected, unmarshaled, "unmarshaled mismatch for %v", tc.value)
+	}
+}
+
+func TestUnmarshalXML(t *testing.T) {
+	var c xmlContact
+
+	// xsi:nil with or without a namespace declaration, and empty elements
+	err := xml.Unmarshal([]byte(`<contact><name xsi:nil="true"/><age xmlns:i="http://www.w3.org/2001/XMLSchema-instance" i:nil="1">`+
+		`</age><balance></balance><active xsi:nil="false">true</active></contact>`), &c)
+	assert.NoError(t, err)
+	assert.Equal(t, null.NullString, c.Name)
+	assert.Equal(t, null.NullInt, c.Age)
+	assert.Equal(t, null.NullFloat64, c.Balance)
+	assert.Equal(t, null.Bool(true), c.Active)
+
+	err = xml.Unmarshal([]byte(`<contact><age>foo</age></contact>`), &c)
+	assert.Error(t, err)
+
+	err = xml.Unmarshal([]byte(`<contact id="foo"></contact>`), &c)
+	assert.Error(t, err)
+}
+
+type CustomXMLID int64
+
+func (i *CustomXMLID) UnmarshalText(b []byte) error { return null.UnmarshalIntText(b, i) }
+func (i CustomXMLID) MarshalText() ([]byte, error)  { return null.MarshalIntText(i) }
+func (i *CustomXMLID) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return null.UnmarshalXML(i, d, start)
+}
+func (i CustomXMLID) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return null.MarshalXML(i, e, start)
+}
+func (i CustomXMLID) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return null.MarshalXMLAttr(i, name)
+}
+
+func TestCustomXML(t *testing.T) {
+	type item struct {
+		XMLName xml.Name    `xml:"item"`
+		ID      CustomXMLID `xml:"id,attr"`
+		Parent  CustomXMLID `xml:"parent"`
+	}
+
+	b, err := xml.Marshal(item{ID: 12, Parent: 34})
+	assert.NoError(t, err)
+	assert.Equal(t, `<item id="12"><parent>34</parent></item>`, string(b))
+
+	b, err = xml.Marshal(item{})
+	assert.NoError(t, err)
+	assert.Equal(t, `<item><parent xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></parent></item>`, string(b))
+
+	var i item
+	assert.NoError(t, xml.Unmarshal([]byte(`<item id="12"><parent>34</parent></item>`), &i))
+	assert.Equal(t, CustomXMLID(12), i.ID)
+	assert.Equal(t, CustomXMLID(34), i.Parent)
+
+	assert.NoError(t, xml.Unmarshal([]byte(`<item id=""><parent xsi:nil="true" /></item>`), &i))
+	assert.Equal(t, CustomXMLID(0), i.ID)
+	assert.Equal(t, CustomXMLID(0), i.Parent)
+}